@@ -0,0 +1,108 @@
+package tinyjson
+
+import (
+	"errors"
+
+	"github.com/CosmWasm/tinyjson/bech32"
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// AddrPrefix is the human-readable part every decoded Addr must have, unless the lexer sets its
+// own AddrPrefix. An empty prefix accepts any prefix. It is not safe to change it concurrently
+// with decoding.
+var AddrPrefix string
+
+var (
+	ErrAddrNotLowercase = jlexer.NewError("address must be lowercase")
+	ErrAddrPrefix       = jlexer.NewError("address has unexpected prefix")
+)
+
+// Addr is a bech32 or bech32m encoded account or contract address. Decoding validates the
+// checksum, that the address is lowercase and that it has the expected prefix. The empty
+// string decodes to the zero Addr.
+type Addr string
+
+// Validate checks that a is a valid lowercase bech32 or bech32m address with the human-readable
+// part prefix, or any prefix if prefix is empty.
+func (a Addr) Validate(prefix string) error {
+	_, hrp, _, err := bech32.Decode(string(a))
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(a); i++ {
+		if 'A' <= a[i] && a[i] <= 'Z' {
+			return ErrAddrNotLowercase
+		}
+	}
+	if prefix != "" && hrp != prefix {
+		return ErrAddrPrefix
+	}
+	return nil
+}
+
+// addrError is the kind of the errors reported when a decoded Addr is invalid. It is an
+// ErrInvalidValue that also matches the cause returned by Validate.
+type addrError struct {
+	cause error
+}
+
+func (e addrError) Error() string {
+	return e.cause.Error()
+}
+
+func (e addrError) Is(target error) bool {
+	return errors.Is(e.cause, target)
+}
+
+func (e addrError) Unwrap() error {
+	return jlexer.ErrInvalidValue
+}
+
+// String returns the address as a string.
+func (a Addr) String() string {
+	return string(a)
+}
+
+// MarshalTinyJSON does JSON marshaling using tinyjson interface.
+func (a Addr) MarshalTinyJSON(w *jwriter.Writer) {
+	w.String(string(a))
+}
+
+// UnmarshalTinyJSON does JSON unmarshaling using tinyjson interface.
+func (a *Addr) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	s := l.String()
+	if !l.Ok() {
+		return
+	}
+	if s != "" {
+		prefix := l.AddrPrefix
+		if prefix == "" {
+			prefix = AddrPrefix
+		}
+		if err := Addr(s).Validate(prefix); err != nil {
+			l.AddNonFatalError(&jlexer.LexerError{
+				Offset: l.TokenStart(),
+				Reason: err.Error(),
+				Err:    addrError{err},
+				Data:   s,
+			})
+			return
+		}
+	}
+	*a = Addr(s)
+}
+
+// MarshalJSON implements encoding/json.Marshaler interface.
+func (a Addr) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	a.MarshalTinyJSON(&w)
+	return w.BuildBytes()
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler interface.
+func (a *Addr) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	a.UnmarshalTinyJSON(&l)
+	return l.Error()
+}
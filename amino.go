@@ -0,0 +1,14 @@
+package tinyjson
+
+import "errors"
+
+// AminoNamer is implemented by types registered with an Amino JSON type name. Encoders generated
+// in Amino JSON mode wrap such values into {"type":...,"value":...} objects when they are encoded
+// through an interface.
+type AminoNamer interface {
+	AminoName() string
+}
+
+// ErrAminoNotMarshaler is reported by Amino JSON encoders for interface values that do not
+// implement the Marshaler interface.
+var ErrAminoNotMarshaler = errors.New("tinyjson: amino json value does not implement tinyjson.Marshaler")
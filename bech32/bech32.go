@@ -0,0 +1,197 @@
+// Package bech32 implements the bech32 (BIP 173) and bech32m (BIP 350) address formats without
+// using reflection, so that it can be used from TinyGo contracts.
+package bech32
+
+import "github.com/CosmWasm/tinyjson/jlexer"
+
+// Encoding is the checksum variant of a bech32 string.
+type Encoding int
+
+const (
+	Bech32  Encoding = 1
+	Bech32m Encoding = 2
+)
+
+const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants the polymod of a valid string results in.
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+var (
+	ErrMixedCase     = jlexer.NewError("bech32: mixed case")
+	ErrInvalidLength = jlexer.NewError("bech32: invalid length")
+	ErrInvalidHRP    = jlexer.NewError("bech32: invalid human-readable part")
+	ErrInvalidChar   = jlexer.NewError("bech32: invalid character")
+	ErrChecksum      = jlexer.NewError("bech32: invalid checksum")
+	ErrPadding       = jlexer.NewError("bech32: invalid padding")
+)
+
+// charsetRev maps characters to their 5-bit values, -1 for characters outside the charset.
+var charsetRev = func() (rev [128]int8) {
+	for i := range rev {
+		rev[i] = -1
+	}
+	for i := 0; i < len(charset); i++ {
+		rev[charset[i]] = int8(i)
+	}
+	return rev
+}()
+
+func polymod(values []byte, chk uint32) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// hrpPolymod returns the polymod state after processing the expanded human-readable part.
+func hrpPolymod(hrp string) uint32 {
+	chk := uint32(1)
+	for i := 0; i < len(hrp); i++ {
+		chk = polymod([]byte{hrp[i] >> 5}, chk)
+	}
+	chk = polymod([]byte{0}, chk)
+	for i := 0; i < len(hrp); i++ {
+		chk = polymod([]byte{hrp[i] & 31}, chk)
+	}
+	return chk
+}
+
+func (enc Encoding) constant() uint32 {
+	if enc == Bech32m {
+		return bech32mConst
+	}
+	return bech32Const
+}
+
+// Encode encodes data as a lowercase bech32 or bech32m string with the human-readable part hrp.
+func Encode(enc Encoding, hrp string, data []byte) (string, error) {
+	if len(hrp) == 0 {
+		return "", ErrInvalidHRP
+	}
+	for i := 0; i < len(hrp); i++ {
+		c := hrp[i]
+		if c < 33 || c > 126 || 'A' <= c && c <= 'Z' {
+			return "", ErrInvalidHRP
+		}
+	}
+
+	values := ConvertBits(data, 8, 5, true)
+	chk := polymod(values, hrpPolymod(hrp))
+	chk = polymod([]byte{0, 0, 0, 0, 0, 0}, chk) ^ enc.constant()
+
+	ret := make([]byte, 0, len(hrp)+1+len(values)+6)
+	ret = append(ret, hrp...)
+	ret = append(ret, '1')
+	for _, v := range values {
+		ret = append(ret, charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		ret = append(ret, charset[(chk>>uint(5*(5-i)))&31])
+	}
+	return string(ret), nil
+}
+
+// Decode decodes a bech32 or bech32m string, returning the detected encoding, the lowercase
+// human-readable part and the data. Strings are accepted in either all lowercase or all
+// uppercase.
+func Decode(s string) (enc Encoding, hrp string, data []byte, err error) {
+	lower, upper := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 33 || c > 126 {
+			return 0, "", nil, ErrInvalidChar
+		}
+		lower = lower || 'a' <= c && c <= 'z'
+		upper = upper || 'A' <= c && c <= 'Z'
+	}
+	if lower && upper {
+		return 0, "", nil, ErrMixedCase
+	}
+
+	sep := -1
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == '1' {
+			sep = i
+			break
+		}
+	}
+	if sep < 1 {
+		return 0, "", nil, ErrInvalidHRP
+	}
+	if len(s)-sep-1 < 6 {
+		return 0, "", nil, ErrInvalidLength
+	}
+
+	hrpBytes := []byte(s[:sep])
+	for i, c := range hrpBytes {
+		if 'A' <= c && c <= 'Z' {
+			hrpBytes[i] = c + 'a' - 'A'
+		}
+	}
+	hrp = string(hrpBytes)
+
+	values := make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		v := charsetRev[c]
+		if v < 0 {
+			return 0, "", nil, ErrInvalidChar
+		}
+		values = append(values, byte(v))
+	}
+
+	switch polymod(values, hrpPolymod(hrp)) {
+	case bech32Const:
+		enc = Bech32
+	case bech32mConst:
+		enc = Bech32m
+	default:
+		return 0, "", nil, ErrChecksum
+	}
+
+	data = ConvertBits(values[:len(values)-6], 5, 8, false)
+	if data == nil {
+		return 0, "", nil, ErrPadding
+	}
+	return enc, hrp, data, nil
+}
+
+// ConvertBits regroups data from fromBits-bit to toBits-bit groups. If pad is false, it returns
+// nil if the input has incomplete groups or non-zero padding bits.
+func ConvertBits(data []byte, fromBits, toBits uint, pad bool) []byte {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<toBits - 1
+
+	ret := make([]byte, 0, (uint(len(data))*fromBits+toBits-1)/toBits)
+	for _, v := range data {
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			ret = append(ret, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			ret = append(ret, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil
+	}
+	return ret
+}
@@ -0,0 +1,71 @@
+package bech32
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecodeValid(t *testing.T) {
+	for i, test := range []struct {
+		s   string
+		enc Encoding
+	}{
+		{s: "A12UEL5L", enc: Bech32},
+		{s: "a12uel5l", enc: Bech32},
+		{s: "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", enc: Bech32},
+		{s: "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", enc: Bech32},
+		{s: "A1LQFN3A", enc: Bech32m},
+		{s: "a1lqfn3a", enc: Bech32m},
+		{s: "abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", enc: Bech32m},
+	} {
+		enc, hrp, _, err := Decode(test.s)
+		if err != nil {
+			t.Errorf("[%d, %q] Decode() error: %v", i, test.s, err)
+			continue
+		}
+		if enc != test.enc {
+			t.Errorf("[%d, %q] Decode() encoding = %v; want %v", i, test.s, enc, test.enc)
+		}
+		if want := strings.ToLower(test.s[:strings.LastIndexByte(test.s, '1')]); hrp != want {
+			t.Errorf("[%d, %q] Decode() hrp = %q; want %q", i, test.s, hrp, want)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for i, test := range []struct {
+		s    string
+		want error
+	}{
+		{s: "a12UEL5L", want: ErrMixedCase},
+		{s: "1nwldj5", want: ErrInvalidHRP},
+		{s: "pzry9x0s0muk", want: ErrInvalidHRP},
+		{s: "a12uel5m", want: ErrChecksum},
+		{s: "li1dgmt3", want: ErrInvalidLength},
+		{s: "x1b4n0q5v", want: ErrInvalidChar},
+		{s: "A1G7SGD8", want: ErrChecksum},
+		{s: "abc1\x7f0aaaaaa", want: ErrInvalidChar},
+	} {
+		if _, _, _, err := Decode(test.s); err != test.want {
+			t.Errorf("[%d, %q] Decode() error = %v; want %v", i, test.s, err, test.want)
+		}
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	data := []byte{0x00, 0x14, 0x75, 0x1e, 0x76, 0xe8, 0x19, 0x91, 0x96, 0xd4, 0x54}
+	for _, enc := range []Encoding{Bech32, Bech32m} {
+		s, err := Encode(enc, "wasm", data)
+		if err != nil {
+			t.Fatalf("Encode() error: %v", err)
+		}
+		gotEnc, hrp, got, err := Decode(s)
+		if err != nil {
+			t.Fatalf("Decode(%q) error: %v", s, err)
+		}
+		if gotEnc != enc || hrp != "wasm" || !bytes.Equal(got, data) {
+			t.Errorf("Decode(%q) = %v, %q, %x; want %v, %q, %x", s, gotEnc, hrp, got, enc, "wasm", data)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package tinyjson
+
+import (
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// Binary is binary data encoded as a base64 string with standard padding, matching the Binary
+// type of cosmwasm-std. Decoding only accepts the canonical encoding.
+type Binary []byte
+
+// MarshalTinyJSON does JSON marshaling using tinyjson interface.
+func (v Binary) MarshalTinyJSON(w *jwriter.Writer) {
+	if v == nil {
+		w.RawString(`""`)
+		return
+	}
+	w.Base64Bytes(v)
+}
+
+// UnmarshalTinyJSON does JSON unmarshaling using tinyjson interface.
+func (v *Binary) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	if l.IsNull() {
+		l.Skip()
+		*v = nil
+		return
+	}
+	*v = l.Base64Bytes()
+}
+
+// MarshalJSON implements encoding/json.Marshaler interface.
+func (v Binary) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	v.MarshalTinyJSON(&w)
+	return w.BuildBytes()
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler interface.
+func (v *Binary) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	v.UnmarshalTinyJSON(&l)
+	return l.Error()
+}
+
+// HexBinary is binary data encoded as a lowercase hex string, matching the HexBinary type of
+// cosmwasm-std. Decoding only accepts lowercase hex digits.
+type HexBinary []byte
+
+// MarshalTinyJSON does JSON marshaling using tinyjson interface.
+func (v HexBinary) MarshalTinyJSON(w *jwriter.Writer) {
+	if v == nil {
+		w.RawString(`""`)
+		return
+	}
+	w.HexBytes(v)
+}
+
+// UnmarshalTinyJSON does JSON unmarshaling using tinyjson interface.
+func (v *HexBinary) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	if l.IsNull() {
+		l.Skip()
+		*v = nil
+		return
+	}
+	*v = l.HexBytes()
+}
+
+// MarshalJSON implements encoding/json.Marshaler interface.
+func (v HexBinary) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	v.MarshalTinyJSON(&w)
+	return w.BuildBytes()
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler interface.
+func (v *HexBinary) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	v.UnmarshalTinyJSON(&l)
+	return l.Error()
+}
@@ -6,22 +6,37 @@
 package bootstrap
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go/format"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/CosmWasm/tinyjson/gen/typeparam"
 )
 
 const genPackage = "github.com/CosmWasm/tinyjson/gen"
 const pkgWriter = "github.com/CosmWasm/tinyjson/jwriter"
 const pkgLexer = "github.com/CosmWasm/tinyjson/jlexer"
+const pkgTinyJSON = "github.com/CosmWasm/tinyjson"
+const pkgTypeParam = "github.com/CosmWasm/tinyjson/gen/typeparam"
 
 var buildFlagsRegexp = regexp.MustCompile("'.+'|\".+\"|\\S+")
 
+// ErrStale is returned by Run in check mode when the output file is not what the generator
+// produces.
+var ErrStale = errors.New("generated file is out of date")
+
 type Generator struct {
 	PkgPath, PkgName string
 	Types            []string
@@ -29,9 +44,40 @@ type Generator struct {
 	NoStdMarshalers          bool
 	SnakeCase                bool
 	LowerCamelCase           bool
+	KebabCase                bool
+	ScreamingSnakeCase       bool
+	PascalCase               bool
 	OmitEmpty                bool
 	DisallowUnknownFields    bool
+	ResetOnDecode            bool
+	InterfaceValue           bool
+	Metadata                 bool
 	SkipMemberNameUnescaping bool
+	AminoJSON                bool
+	Proto3JSON               bool
+
+	// AminoNames maps type names to their Amino JSON names.
+	AminoNames map[string]string
+
+	// FieldNamer is a Go expression of a custom gen.FieldNamer, qualified with the import path of
+	// its package, e.g. "example.com/names.Namer{}".
+	FieldNamer string
+
+	// Renames maps field names, or type and field names, to their member names.
+	Renames map[string]string
+
+	// External lists the fully qualified names of types declared in other packages, e.g.
+	// "example.com/bank.Coin", to generate standalone EncodeCoin and DecodeCoin functions for.
+	External []string
+
+	// Codecs maps fully qualified type names to the codecs registered for them.
+	Codecs map[string]Codec
+
+	// TypeOptions maps type names to the generator options overridden for them.
+	TypeOptions map[string][]string
+
+	// TypeParams maps the names of generic types to their type parameters, e.g. "T any".
+	TypeParams map[string][]string
 
 	OutName       string
 	BuildTags     string
@@ -39,18 +85,71 @@ type Generator struct {
 
 	StubsOnly   bool
 	LeaveTemps  bool
+	Check       bool // Compare the output file with the generated code instead of writing it.
+	GenTests    bool // Also generate tests comparing the encodings with encoding/json.
 	NoFormat    bool
 	SimpleBytes bool
 }
 
+// Codec is the encoder and decoder of a type, function names or snippets of code, as described by
+// gen.Generator.RegisterCodec.
+type Codec struct {
+	Encoder string `json:"encoder"`
+	Decoder string `json:"decoder"`
+}
+
+// external is a type declared in another package, given in Generator.External.
+type external struct {
+	pkgPath, typeName string
+	alias             string // Import alias of the package.
+	name              string // Name of the type in the names of the generated functions.
+}
+
+// externals returns the types given in External. The generated functions are named after the
+// types, and their packages when several types have the same name.
+func (g *Generator) externals() ([]external, error) {
+	var exts []external
+	aliases := map[string]string{}
+	count := map[string]int{}
+	for _, qualified := range g.External {
+		i := strings.LastIndexByte(qualified, '.')
+		if i <= 0 || i < strings.LastIndexByte(qualified, '/') {
+			return nil, fmt.Errorf("external type %q is not qualified with the import path of its package", qualified)
+		}
+		ext := external{pkgPath: qualified[:i], typeName: qualified[i+1:]}
+		if aliases[ext.pkgPath] == "" {
+			aliases[ext.pkgPath] = fmt.Sprintf("ext%d", len(aliases))
+		}
+		ext.alias = aliases[ext.pkgPath]
+		count[ext.typeName]++
+		exts = append(exts, ext)
+	}
+
+	for i, ext := range exts {
+		exts[i].name = ext.typeName
+		if count[ext.typeName] > 1 {
+			pkg := strings.Map(func(r rune) rune {
+				if unicode.IsLetter(r) || unicode.IsDigit(r) {
+					return r
+				}
+				return -1
+			}, path.Base(ext.pkgPath))
+			if pkg != "" {
+				pkg = strings.ToUpper(pkg[:1]) + pkg[1:]
+			}
+			exts[i].name = pkg + ext.typeName
+		}
+	}
+	return exts, nil
+}
+
 // writeStub outputs an initial stub for marshalers/unmarshalers so that the package
 // using marshalers/unmarshales compiles correctly for boostrapping code.
-func (g *Generator) writeStub() error {
-	f, err := os.Create(g.OutName)
+func (g *Generator) writeStub(f io.Writer) error {
+	exts, err := g.externals()
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
 	if g.BuildTags != "" {
 		fmt.Fprintln(f, "// +build ", g.BuildTags)
@@ -61,35 +160,101 @@ func (g *Generator) writeStub() error {
 	fmt.Fprintln(f)
 	fmt.Fprintln(f, "package ", g.PkgName)
 
-	if len(g.Types) > 0 {
+	if len(g.Types) > 0 || len(exts) > 0 {
 		fmt.Fprintln(f)
 		fmt.Fprintln(f, "import (")
 		fmt.Fprintln(f, `  "`+pkgWriter+`"`)
 		fmt.Fprintln(f, `  "`+pkgLexer+`"`)
+		for _, alias := range externalImports(exts) {
+			fmt.Fprintf(f, "  %s %q\n", alias[0], alias[1])
+		}
+		if g.hasGenericTypes() {
+			fmt.Fprintln(f, `  "`+pkgTypeParam+`"`)
+		}
+		if g.Metadata {
+			fmt.Fprintln(f, `  "`+pkgTinyJSON+`"`)
+		}
 		fmt.Fprintln(f, ")")
 	}
 
 	sort.Strings(g.Types)
 	for _, t := range g.Types {
+		n := len(g.TypeParams[t])
+		if n > typeparam.Max {
+			return fmt.Errorf("%s has %d type parameters, at most %d are supported", t, n, typeparam.Max)
+		}
+
+		// Generic types get stubs for all instantiations and are exported instantiated
+		// with placeholder types.
+		recv, inst := t, t
+		if n > 0 {
+			blanks := make([]string, n)
+			args := make([]string, n)
+			for i := range blanks {
+				blanks[i] = "_"
+				args[i] = fmt.Sprintf("typeparam.P%d", i)
+			}
+			recv += "[" + strings.Join(blanks, ", ") + "]"
+			inst += "[" + strings.Join(args, ", ") + "]"
+		}
+
 		fmt.Fprintln(f)
 		if !g.NoStdMarshalers {
-			fmt.Fprintln(f, "func (", t, ") MarshalJSON() ([]byte, error) { return nil, nil }")
-			fmt.Fprintln(f, "func (*", t, ") UnmarshalJSON([]byte) error { return nil }")
+			fmt.Fprintln(f, "func (", recv, ") MarshalJSON() ([]byte, error) { return nil, nil }")
+			fmt.Fprintln(f, "func (*", recv, ") UnmarshalJSON([]byte) error { return nil }")
 		}
 
-		fmt.Fprintln(f, "func (", t, ") MarshalTinyJSON(w *jwriter.Writer) {}")
-		fmt.Fprintln(f, "func (*", t, ") UnmarshalTinyJSON(l *jlexer.Lexer) {}")
+		fmt.Fprintln(f, "func (", recv, ") MarshalTinyJSON(w *jwriter.Writer) {}")
+		fmt.Fprintln(f, "func (", recv, ") AppendTinyJSON(dst []byte) []byte { return dst }")
+		fmt.Fprintln(f, "func (*", recv, ") UnmarshalTinyJSON(l *jlexer.Lexer) {}")
+		if g.Metadata {
+			fmt.Fprintln(f, "func (", recv, ") TinyJSONTypeInfo() *tinyjson.TypeInfo { return nil }")
+		}
+		fmt.Fprintln(f)
+		fmt.Fprintln(f, "type TinyJSON_exporter_"+t+" *"+inst)
+	}
+
+	for _, ext := range exts {
 		fmt.Fprintln(f)
-		fmt.Fprintln(f, "type TinyJSON_exporter_"+t+" *"+t)
+		fmt.Fprintf(f, "func Encode%s(w *jwriter.Writer, v *%s.%s) {}\n", ext.name, ext.alias, ext.typeName)
+		fmt.Fprintf(f, "func Decode%s(l *jlexer.Lexer, v *%s.%s) {}\n", ext.name, ext.alias, ext.typeName)
 	}
 	return nil
 }
 
-// writeMain creates a .go file that launches the generator if 'go run'.
-func (g *Generator) writeMain() (path string, err error) {
-	f, err := ioutil.TempFile(filepath.Dir(g.OutName), "tinyjson-bootstrap")
+// externalImports returns the aliases and paths of the packages of the external types, in order.
+func externalImports(exts []external) [][2]string {
+	var imports [][2]string
+	seen := map[string]bool{}
+	for _, ext := range exts {
+		if !seen[ext.alias] {
+			seen[ext.alias] = true
+			imports = append(imports, [2]string{ext.alias, ext.pkgPath})
+		}
+	}
+	return imports
+}
+
+// hasGenericTypes reports whether any of the types is generic.
+func (g *Generator) hasGenericTypes() bool {
+	for _, t := range g.Types {
+		if len(g.TypeParams[t]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMain outputs a .go file that launches the generator if 'go run', writing the generated
+// tests to testsPath if not empty.
+func (g *Generator) writeMain(f io.Writer, testsPath string) error {
+	namerPkg, namer, err := splitFieldNamer(g.FieldNamer)
 	if err != nil {
-		return "", err
+		return err
+	}
+	exts, err := g.externals()
+	if err != nil {
+		return err
 	}
 
 	fmt.Fprintln(f, "// +build ignore")
@@ -104,6 +269,12 @@ func (g *Generator) writeMain() (path string, err error) {
 	fmt.Fprintln(f, `  "os"`)
 	fmt.Fprintln(f)
 	fmt.Fprintf(f, "  %q\n", genPackage)
+	if namerPkg != "" {
+		fmt.Fprintf(f, "  fieldnamer %q\n", namerPkg)
+	}
+	for _, alias := range externalImports(exts) {
+		fmt.Fprintf(f, "  %s %q\n", alias[0], alias[1])
+	}
 	if len(g.Types) > 0 {
 		fmt.Fprintln(f)
 		fmt.Fprintf(f, "  pkg %q\n", g.PkgPath)
@@ -116,12 +287,49 @@ func (g *Generator) writeMain() (path string, err error) {
 	if g.BuildTags != "" {
 		fmt.Fprintf(f, "  g.SetBuildTags(%q)\n", g.BuildTags)
 	}
+	if g.Proto3JSON {
+		fmt.Fprintln(f, "  g.Proto3JSON()")
+	}
 	if g.SnakeCase {
 		fmt.Fprintln(f, "  g.UseSnakeCase()")
 	}
 	if g.LowerCamelCase {
 		fmt.Fprintln(f, "  g.UseLowerCamelCase()")
 	}
+	if g.KebabCase {
+		fmt.Fprintln(f, "  g.UseKebabCase()")
+	}
+	if g.ScreamingSnakeCase {
+		fmt.Fprintln(f, "  g.UseScreamingSnakeCase()")
+	}
+	if g.PascalCase {
+		fmt.Fprintln(f, "  g.UsePascalCase()")
+	}
+	if namerPkg != "" {
+		fmt.Fprintf(f, "  g.SetFieldNamer(fieldnamer.%s)\n", namer)
+	}
+	if len(g.Renames) > 0 {
+		names := make([]string, 0, len(g.Renames))
+		for n := range g.Renames {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		fmt.Fprintln(f, "  g.RenameFields(map[string]string{")
+		for _, n := range names {
+			fmt.Fprintf(f, "    %q: %q,\n", n, g.Renames[n])
+		}
+		fmt.Fprintln(f, "  })")
+	}
+	if len(g.Codecs) > 0 {
+		names := make([]string, 0, len(g.Codecs))
+		for n := range g.Codecs {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			fmt.Fprintf(f, "  g.RegisterCodec(%q, %q, %q)\n", n, g.Codecs[n].Encoder, g.Codecs[n].Decoder)
+		}
+	}
 	if g.OmitEmpty {
 		fmt.Fprintln(f, "  g.OmitEmpty()")
 	}
@@ -131,86 +339,243 @@ func (g *Generator) writeMain() (path string, err error) {
 	if g.DisallowUnknownFields {
 		fmt.Fprintln(f, "  g.DisallowUnknownFields()")
 	}
+	if g.ResetOnDecode {
+		fmt.Fprintln(f, "  g.ResetOnDecode()")
+	}
+	if g.InterfaceValue {
+		fmt.Fprintln(f, "  g.InterfaceValue()")
+	}
+	if g.Metadata {
+		fmt.Fprintln(f, "  g.Metadata()")
+	}
 	if g.SimpleBytes {
 		fmt.Fprintln(f, "  g.SimpleBytes()")
 	}
 	if g.SkipMemberNameUnescaping {
 		fmt.Fprintln(f, "  g.SkipMemberNameUnescaping()")
 	}
+	if g.AminoJSON {
+		fmt.Fprintln(f, "  g.AminoJSON()")
+	}
 
 	sort.Strings(g.Types)
 	for _, v := range g.Types {
 		fmt.Fprintln(f, "  g.Add(pkg.TinyJSON_exporter_"+v+"(nil))")
+		if params := g.TypeParams[v]; len(params) > 0 {
+			fmt.Fprintf(f, "  g.SetTypeParams(pkg.TinyJSON_exporter_%s(nil)", v)
+			for _, p := range params {
+				fmt.Fprintf(f, ", %q", p)
+			}
+			fmt.Fprintln(f, ")")
+		}
+		if options := g.TypeOptions[v]; len(options) > 0 {
+			fmt.Fprintf(f, "  g.SetTypeOptions(pkg.TinyJSON_exporter_%s(nil)", v)
+			for _, o := range options {
+				fmt.Fprintf(f, ", %q", o)
+			}
+			fmt.Fprintln(f, ")")
+		}
+		if name := g.AminoNames[v]; name != "" {
+			fmt.Fprintf(f, "  g.RegisterAminoName(pkg.TinyJSON_exporter_%s(nil), %q)\n", v, name)
+		}
+	}
+
+	for _, ext := range exts {
+		fmt.Fprintf(f, "  g.AddExternal((*%s.%s)(nil), %q)\n", ext.alias, ext.typeName, ext.name)
 	}
 
 	fmt.Fprintln(f, "  if err := g.Run(os.Stdout); err != nil {")
 	fmt.Fprintln(f, "    fmt.Fprintln(os.Stderr, err)")
 	fmt.Fprintln(f, "    os.Exit(1)")
 	fmt.Fprintln(f, "  }")
+	if testsPath != "" {
+		fmt.Fprintf(f, "  tests, err := os.Create(%q)\n", testsPath)
+		fmt.Fprintln(f, "  if err == nil {")
+		fmt.Fprintln(f, "    err = g.RunTests(tests)")
+		fmt.Fprintln(f, "  }")
+		fmt.Fprintln(f, "  if err == nil {")
+		fmt.Fprintln(f, "    err = tests.Close()")
+		fmt.Fprintln(f, "  }")
+		fmt.Fprintln(f, "  if err != nil {")
+		fmt.Fprintln(f, "    fmt.Fprintln(os.Stderr, err)")
+		fmt.Fprintln(f, "    os.Exit(1)")
+		fmt.Fprintln(f, "  }")
+	}
 	fmt.Fprintln(f, "}")
+	return nil
+}
 
-	src := f.Name()
-	if err := f.Close(); err != nil {
-		return src, err
+// splitFieldNamer splits the expression of a custom field namer into the import path of its
+// package and the expression relative to the package.
+func splitFieldNamer(expr string) (pkgPath, rel string, err error) {
+	if expr == "" {
+		return "", "", nil
+	}
+	// The import path ends with the last slash before the expression proper, which may
+	// contain slashes in arguments.
+	prefix := expr
+	if i := strings.IndexAny(prefix, "({[\"` "); i != -1 {
+		prefix = prefix[:i]
+	}
+	i := strings.LastIndexByte(prefix, '/') + 1
+	j := strings.IndexByte(prefix[i:], '.')
+	if j <= 0 {
+		return "", "", fmt.Errorf("field namer %q is not qualified with the import path of its package", expr)
 	}
+	return expr[:i+j], expr[i+j+1:], nil
+}
+
+// writeFile creates the file with the given name and fills it with write.
+func writeFile(name string, write func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
 
-	dest := src + ".go"
-	return dest, os.Rename(src, dest)
+// TestsName returns the name of the file the tests are generated to with GenTests: the output
+// file name with a _test suffix.
+func (g *Generator) TestsName() string {
+	return strings.TrimSuffix(g.OutName, ".go") + "_test.go"
 }
 
 func (g *Generator) Run() error {
-	if err := g.writeStub(); err != nil {
+	if g.StubsOnly && !g.Check {
+		return writeFile(g.OutName, g.writeStub)
+	}
+
+	out, tests, err := g.generate()
+	if err != nil {
 		return err
 	}
-	if g.StubsOnly {
-		return nil
+	if g.Check {
+		err := g.check(g.OutName, out)
+		if g.GenTests {
+			if testsErr := g.check(g.TestsName(), tests); err == nil {
+				err = testsErr
+			}
+		}
+		return err
 	}
+	if err := ioutil.WriteFile(g.OutName, out, 0644); err != nil {
+		return err
+	}
+	if g.GenTests {
+		return ioutil.WriteFile(g.TestsName(), tests, 0644)
+	}
+	return nil
+}
+
+// Generate runs the generator and returns the contents of the output file. The stubs the
+// package needs to compile during generation and the code launching the generator are
+// written to a temporary directory and overlaid on the package, which is left untouched.
+func (g *Generator) Generate() ([]byte, error) {
+	out, _, err := g.generate()
+	return out, err
+}
 
-	path, err := g.writeMain()
+// generate runs the generator and returns the contents of the output file and, with GenTests,
+// of the tests file.
+func (g *Generator) generate() (out, tests []byte, err error) {
+	outPath, err := filepath.Abs(g.OutName)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+	pkgDir := filepath.Dir(outPath)
+
+	dir, err := ioutil.TempDir("", "tinyjson-bootstrap")
+	if err != nil {
+		return nil, nil, err
+	}
+	if g.LeaveTemps {
+		fmt.Fprintln(os.Stderr, "tinyjson: temporary files left in", dir)
+	} else {
+		defer os.RemoveAll(dir)
+	}
+
+	stubPath := filepath.Join(dir, "stub.go")
+	if err := writeFile(stubPath, g.writeStub); err != nil {
+		return nil, nil, err
 	}
-	if !g.LeaveTemps {
-		defer os.Remove(path)
+	var testsPath string
+	if g.GenTests {
+		testsPath = filepath.Join(dir, "tests.go")
+	}
+	mainPath := filepath.Join(dir, "main.go")
+	if err := writeFile(mainPath, func(f io.Writer) error { return g.writeMain(f, testsPath) }); err != nil {
+		return nil, nil, err
 	}
 
-	f, err := os.Create(g.OutName + ".tmp")
+	// The main file is run from the package directory, to be built within the module of
+	// the package, under a name that is unlikely to be taken.
+	mainName := filepath.Base(dir) + ".go"
+	overlay, err := json.Marshal(map[string]map[string]string{
+		"Replace": {
+			outPath:                         stubPath,
+			filepath.Join(pkgDir, mainName): mainPath,
+		},
+	})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	if !g.LeaveTemps {
-		defer os.Remove(f.Name()) // will not remove after rename
+	overlayPath := filepath.Join(dir, "overlay.json")
+	if err := ioutil.WriteFile(overlayPath, overlay, 0644); err != nil {
+		return nil, nil, err
 	}
 
-	execArgs := []string{"run"}
+	execArgs := []string{"run", "-overlay", overlayPath}
 	if g.GenBuildFlags != "" {
 		buildFlags := buildFlagsRegexp.FindAllString(g.GenBuildFlags, -1)
 		execArgs = append(execArgs, buildFlags...)
 	}
-	execArgs = append(execArgs, "-tags", g.BuildTags, filepath.Base(path))
+	execArgs = append(execArgs, "-tags", g.BuildTags, mainName)
 	cmd := exec.Command("go", execArgs...)
 
-	cmd.Stdout = f
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
 	cmd.Stderr = os.Stderr
-	cmd.Dir = filepath.Dir(path)
+	cmd.Dir = pkgDir
 	if err = cmd.Run(); err != nil {
-		return err
+		return nil, nil, err
+	}
+
+	if out, err = g.format(stdout.Bytes()); err != nil {
+		return nil, nil, err
+	}
+	if g.GenTests {
+		if tests, err = ioutil.ReadFile(testsPath); err != nil {
+			return nil, nil, err
+		}
+		if tests, err = g.format(tests); err != nil {
+			return nil, nil, err
+		}
 	}
-	f.Close()
+	return out, tests, nil
+}
 
-	// move unformatted file to out path
+// format formats the generated code src, unless NoFormat is set.
+func (g *Generator) format(src []byte) ([]byte, error) {
 	if g.NoFormat {
-		return os.Rename(f.Name(), g.OutName)
+		return src, nil
 	}
+	return format.Source(src)
+}
 
-	// format file and write to out path
-	in, err := ioutil.ReadFile(f.Name())
-	if err != nil {
+// check compares the file with the given name with the generated code, writing their
+// differences to the standard output.
+func (g *Generator) check(name string, out []byte) error {
+	old, err := ioutil.ReadFile(name)
+	if err != nil && !os.IsNotExist(err) {
 		return err
 	}
-	out, err := format.Source(in)
-	if err != nil {
-		return err
+	if bytes.Equal(old, out) {
+		return nil
 	}
-	return ioutil.WriteFile(g.OutName, out, 0644)
+	os.Stdout.Write(diff(name, old, out))
+	return fmt.Errorf("%s: %w", name, ErrStale)
 }
@@ -0,0 +1,53 @@
+package bootstrap
+
+import "testing"
+
+func TestSplitFieldNamer(t *testing.T) {
+	for _, test := range []struct {
+		expr, pkgPath, rel string
+	}{
+		{"", "", ""},
+		{"example.com/names.Namer{}", "example.com/names", "Namer{}"},
+		{"names.Namer{}", "names", "Namer{}"},
+		{`example.com/x/names.New("a/b.c")`, "example.com/x/names", `New("a/b.c")`},
+	} {
+		pkgPath, rel, err := splitFieldNamer(test.expr)
+		if err != nil || pkgPath != test.pkgPath || rel != test.rel {
+			t.Errorf("splitFieldNamer(%q) = %q, %q, %v; want %q, %q", test.expr, pkgPath, rel, err, test.pkgPath, test.rel)
+		}
+	}
+
+	for _, expr := range []string{"Namer{}", "example.com/names"} {
+		if _, _, err := splitFieldNamer(expr); err == nil {
+			t.Errorf("splitFieldNamer(%q) error = nil", expr)
+		}
+	}
+}
+
+func TestExternals(t *testing.T) {
+	g := Generator{External: []string{"example.com/bank.Coin", "example.com/bank.Balance", "example.com/ibc-transfer.Coin"}}
+	exts, err := g.externals()
+	if err != nil {
+		t.Fatalf("externals() error: %v", err)
+	}
+	want := []external{
+		{pkgPath: "example.com/bank", typeName: "Coin", alias: "ext0", name: "BankCoin"},
+		{pkgPath: "example.com/bank", typeName: "Balance", alias: "ext0", name: "Balance"},
+		{pkgPath: "example.com/ibc-transfer", typeName: "Coin", alias: "ext1", name: "IbctransferCoin"},
+	}
+	if len(exts) != len(want) {
+		t.Fatalf("externals() = %+v, want %+v", exts, want)
+	}
+	for i := range want {
+		if exts[i] != want[i] {
+			t.Errorf("externals()[%d] = %+v, want %+v", i, exts[i], want[i])
+		}
+	}
+
+	for _, name := range []string{"Coin", "example.com/bank"} {
+		g := Generator{External: []string{name}}
+		if _, err := g.externals(); err == nil {
+			t.Errorf("externals() of %q error = nil", name)
+		}
+	}
+}
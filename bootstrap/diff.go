@@ -0,0 +1,163 @@
+package bootstrap
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around changes.
+const diffContext = 3
+
+// maxDiffTable bounds the size of the table used to find the longest common subsequence of
+// the changed lines. Beyond it, all the changed lines are shown as removed and added.
+const maxDiffTable = 1 << 22
+
+// edit is a line of a diff: unchanged (' '), removed ('-') or added ('+').
+type edit struct {
+	op   byte
+	line string
+}
+
+// diff returns the differences between the old and new contents of the named file in the
+// unified format.
+func diff(name string, old, new []byte) []byte {
+	edits := diffLines(splitLines(old), splitLines(new))
+
+	// Line numbers, in the old and new contents, of each edit.
+	oldLine := make([]int, len(edits)+1)
+	newLine := make([]int, len(edits)+1)
+	for i, e := range edits {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if e.op != '+' {
+			oldLine[i+1]++
+		}
+		if e.op != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- %s\n+++ %s (generated)\n", name, name)
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+
+		// A hunk extends to the next change while fewer than two contexts of unchanged
+		// lines separate them.
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(edits) {
+			if edits[end].op != ' ' {
+				end++
+				continue
+			}
+			j := end
+			for j < len(edits) && edits[j].op == ' ' {
+				j++
+			}
+			if j == len(edits) || j-end > 2*diffContext {
+				if end += diffContext; end > j {
+					end = j
+				}
+				break
+			}
+			end = j
+		}
+
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n",
+			hunkRange(oldLine[start], oldLine[end]), hunkRange(newLine[start], newLine[end]))
+		for _, e := range edits[start:end] {
+			buf.WriteByte(e.op)
+			buf.WriteString(e.line)
+			buf.WriteByte('\n')
+		}
+		i = end
+	}
+	return buf.Bytes()
+}
+
+// hunkRange formats the lines from start, excluded, to end of a hunk.
+func hunkRange(start, end int) string {
+	if start == end {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, end-start)
+}
+
+// splitLines splits data into lines, without their line feeds.
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// diffLines returns the edits turning the lines a into b.
+func diffLines(a, b []string) []edit {
+	var edits []edit
+
+	// Generated files mostly change in a few places: only the lines between the common
+	// prefix and suffix are compared.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		edits = append(edits, edit{' ', a[prefix]})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	if (len(ma)+1)*(len(mb)+1) > maxDiffTable {
+		for _, l := range ma {
+			edits = append(edits, edit{'-', l})
+		}
+		for _, l := range mb {
+			edits = append(edits, edit{'+', l})
+		}
+	} else {
+		// lcs[i*w+j] is the length of the longest common subsequence of ma[i:] and mb[j:].
+		w := len(mb) + 1
+		lcs := make([]int32, (len(ma)+1)*w)
+		for i := len(ma) - 1; i >= 0; i-- {
+			for j := len(mb) - 1; j >= 0; j-- {
+				switch {
+				case ma[i] == mb[j]:
+					lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+				case lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+					lcs[i*w+j] = lcs[(i+1)*w+j]
+				default:
+					lcs[i*w+j] = lcs[i*w+j+1]
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < len(ma) || j < len(mb) {
+			switch {
+			case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+				edits = append(edits, edit{' ', ma[i]})
+				i++
+				j++
+			case j == len(mb) || (i < len(ma) && lcs[(i+1)*w+j] >= lcs[i*w+j+1]):
+				edits = append(edits, edit{'-', ma[i]})
+				i++
+			default:
+				edits = append(edits, edit{'+', mb[j]})
+				j++
+			}
+		}
+	}
+
+	for _, l := range a[len(a)-suffix:] {
+		edits = append(edits, edit{' ', l})
+	}
+	return edits
+}
@@ -0,0 +1,51 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	for _, test := range []struct {
+		name, old, new, want string
+	}{
+		{
+			name: "changed line",
+			old:  "a\nb\nc\nd\ne\nf\ng\nh\n",
+			new:  "a\nb\nc\nd\nE\nf\ng\nh\n",
+			want: "@@ -2,7 +2,7 @@\n b\n c\n d\n-e\n+E\n f\n g\n h\n",
+		},
+		{
+			name: "separate hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			new:  "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			want: "@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n@@ -9,4 +10,3 @@\n 9\n 10\n 11\n-12\n",
+		},
+		{
+			name: "merged hunks",
+			old:  "a\nb\nc\nd\ne\n",
+			new:  "A\nb\nc\nd\nE\n",
+			want: "@@ -1,5 +1,5 @@\n-a\n+A\n b\n c\n d\n-e\n+E\n",
+		},
+		{
+			name: "new file",
+			old:  "",
+			new:  "a\nb\n",
+			want: "@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "moved lines",
+			old:  "x\na\nb\ny\n",
+			new:  "x\nb\na\ny\n",
+			want: "@@ -1,4 +1,4 @@\n x\n-a\n b\n+a\n y\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := string(diff("f.go", []byte(test.old), []byte(test.new)))
+			header := "--- f.go\n+++ f.go (generated)\n"
+			if !strings.HasPrefix(got, header) || got[len(header):] != test.want {
+				t.Errorf("diff() =\n%s\nwant\n%s%s", got, header, test.want)
+			}
+		})
+	}
+}
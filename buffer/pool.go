@@ -5,6 +5,7 @@ package buffer
 import (
 	"io"
 	"sync"
+	"sync/atomic"
 )
 
 // PoolConfig contains configuration for the allocation and reuse strategy.
@@ -14,46 +15,63 @@ type PoolConfig struct {
 	MaxSize    int // Maximum chunk size that will be allocated.
 }
 
-var config = PoolConfig{
+var defaultConfig = PoolConfig{
 	StartSize:  128,
 	PooledSize: 512,
 	MaxSize:    32768,
 }
 
-// Reuse pool: chunk size -> pool.
-var buffers = map[int]*sync.Pool{}
+// Allocator provides the chunks buffers are made of.
+type Allocator interface {
+	// Get returns an empty chunk with a capacity of at least size bytes.
+	Get(size int) []byte
+	// Put takes back a chunk that is no longer used.
+	Put(buf []byte)
+}
 
-func initBuffers() {
-	for l := config.PooledSize; l <= config.MaxSize; l *= 2 {
-		buffers[l] = new(sync.Pool)
-	}
+// configured is implemented by allocators that set the chunk sizes of buffers.
+type configured interface {
+	Config() PoolConfig
 }
 
-func init() {
-	initBuffers()
+// Pool is an Allocator reusing chunks through a sync.Pool per chunk size. It is safe for
+// concurrent use.
+type Pool struct {
+	config PoolConfig
+
+	// Reuse pool: chunk size -> pool.
+	buffers map[int]*sync.Pool
 }
 
-// Init sets up a non-default pooling and allocation strategy. Should be run before serialization is done.
-func Init(cfg PoolConfig) {
-	config = cfg
-	initBuffers()
+// NewPool returns a Pool with the given configuration.
+func NewPool(cfg PoolConfig) *Pool {
+	p := &Pool{config: cfg, buffers: map[int]*sync.Pool{}}
+	for l := cfg.PooledSize; l > 0 && l <= cfg.MaxSize; l *= 2 {
+		p.buffers[l] = new(sync.Pool)
+	}
+	return p
+}
+
+// Config returns the configuration of the pool.
+func (p *Pool) Config() PoolConfig {
+	return p.config
 }
 
-// putBuf puts a chunk to reuse pool if it can be reused.
-func putBuf(buf []byte) {
+// Put puts a chunk to reuse pool if it can be reused.
+func (p *Pool) Put(buf []byte) {
 	size := cap(buf)
-	if size < config.PooledSize {
+	if size < p.config.PooledSize {
 		return
 	}
-	if c := buffers[size]; c != nil {
+	if c := p.buffers[size]; c != nil {
 		c.Put(buf[:0])
 	}
 }
 
-// getBuf gets a chunk from reuse pool or creates a new one if reuse failed.
-func getBuf(size int) []byte {
-	if size >= config.PooledSize {
-		if c := buffers[size]; c != nil {
+// Get gets a chunk from reuse pool or creates a new one if reuse failed.
+func (p *Pool) Get(size int) []byte {
+	if size >= p.config.PooledSize {
+		if c := p.buffers[size]; c != nil {
 			v := c.Get()
 			if v != nil {
 				return v.([]byte)
@@ -63,16 +81,109 @@ func getBuf(size int) []byte {
 	return make([]byte, 0, size)
 }
 
+// defaultPool holds the *Pool used by buffers without an allocator.
+var defaultPool atomic.Value
+
+func init() {
+	defaultPool.Store(NewPool(defaultConfig))
+}
+
+// Init sets up a non-default pooling and allocation strategy for buffers without an allocator.
+// Buffers already in use keep their chunk sizes.
+func Init(cfg PoolConfig) {
+	defaultPool.Store(NewPool(cfg))
+}
+
+// DefaultPool returns the pool used by buffers without an allocator.
+func DefaultPool() *Pool {
+	return defaultPool.Load().(*Pool)
+}
+
+// Arena is an Allocator handing out chunks of a single block of memory, all released at once
+// by Reset, e.g. at the end of a contract call. Chunks that do not fit in the rest of the block
+// are allocated normally. Data written to or decoded with chunks of an arena must not be used
+// after it is reset. It is not safe for concurrent use.
+type Arena struct {
+	block  []byte
+	config PoolConfig
+}
+
+// NewArena returns an Arena of size bytes.
+func NewArena(size int) *Arena {
+	return &Arena{block: make([]byte, 0, size), config: defaultConfig}
+}
+
+// Config returns the chunk sizes of buffers using the arena.
+func (a *Arena) Config() PoolConfig {
+	return a.config
+}
+
+// Get returns a chunk from the block, or a new one if the block is exhausted.
+func (a *Arena) Get(size int) []byte {
+	n := len(a.block)
+	if size > cap(a.block)-n {
+		return make([]byte, 0, size)
+	}
+	a.block = a.block[:n+size]
+	return a.block[n : n : n+size]
+}
+
+// Put releases the chunk if it is the last one handed out, so that it can be handed out again.
+func (a *Arena) Put(buf []byte) {
+	n := len(a.block)
+	size := cap(buf)
+	if size == 0 || size > n {
+		return
+	}
+	if &buf[:size][size-1] == &a.block[n-1] {
+		a.block = a.block[:n-size]
+	}
+}
+
+// Reset releases all chunks.
+func (a *Arena) Reset() {
+	a.block = a.block[:0]
+}
+
+// Used returns the number of bytes of the block handed out.
+func (a *Arena) Used() int {
+	return len(a.block)
+}
+
 // Buffer is a buffer optimized for serialization without extra copying.
 type Buffer struct {
 
 	// Buf is the current chunk that can be used for serialization.
 	Buf []byte
 
+	// Alloc provides the chunks of the buffer. If nil, the default pool is used.
+	Alloc Allocator
+
+	// Single makes the buffer grow Buf as a single slice, like append does, instead of chaining
+	// pooled chunks, so that its contents are never copied into a new slice when built. Buf can
+	// be set to a caller-supplied slice to append to.
+	Single bool
+
 	toPool []byte
 	bufs   [][]byte
 }
 
+// allocator returns the allocator of the buffer.
+func (b *Buffer) allocator() Allocator {
+	if b.Alloc != nil {
+		return b.Alloc
+	}
+	return DefaultPool()
+}
+
+// config returns the chunk sizes of the buffer.
+func (b *Buffer) config() PoolConfig {
+	if c, ok := b.allocator().(configured); ok {
+		return c.Config()
+	}
+	return defaultConfig
+}
+
 // EnsureSpace makes sure that the current chunk contains at least s free bytes,
 // possibly creating a new chunk.
 func (b *Buffer) EnsureSpace(s int) {
@@ -82,11 +193,19 @@ func (b *Buffer) EnsureSpace(s int) {
 }
 
 func (b *Buffer) ensureSpaceSlow(s int) {
+	if b.Single {
+		b.grow(s)
+		return
+	}
+
+	alloc := b.allocator()
+	config := b.config()
+
 	l := len(b.Buf)
 	if l > 0 {
 		if cap(b.toPool) != cap(b.Buf) {
 			// Chunk was reallocated, toPool can be pooled.
-			putBuf(b.toPool)
+			alloc.Put(b.toPool)
 		}
 		if cap(b.bufs) == 0 {
 			b.bufs = make([][]byte, 0, 8)
@@ -100,10 +219,21 @@ func (b *Buffer) ensureSpaceSlow(s int) {
 	if l > config.MaxSize {
 		l = config.MaxSize
 	}
-	b.Buf = getBuf(l)
+	b.Buf = alloc.Get(l)
 	b.toPool = b.Buf
 }
 
+// grow reallocates Buf in single slice mode so that it has at least s free bytes.
+func (b *Buffer) grow(s int) {
+	size := 2*cap(b.Buf) + s
+	if start := b.config().StartSize; size < start {
+		size = start
+	}
+	buf := make([]byte, len(b.Buf), size)
+	copy(buf, b.Buf)
+	b.Buf = buf
+}
+
 // AppendByte appends a single byte to buffer.
 func (b *Buffer) AppendByte(data byte) {
 	b.EnsureSpace(1)
@@ -120,6 +250,10 @@ func (b *Buffer) AppendBytes(data []byte) {
 }
 
 func (b *Buffer) appendBytesSlow(data []byte) {
+	if b.Single {
+		b.Buf = append(b.Buf, data...)
+		return
+	}
 	for len(data) > 0 {
 		b.EnsureSpace(1)
 
@@ -143,6 +277,10 @@ func (b *Buffer) AppendString(data string) {
 }
 
 func (b *Buffer) appendStringSlow(data string) {
+	if b.Single {
+		b.Buf = append(b.Buf, data...)
+		return
+	}
 	for len(data) > 0 {
 		b.EnsureSpace(1)
 
@@ -173,10 +311,11 @@ func (b *Buffer) DumpTo(w io.Writer) (written int, err error) {
 	}
 	n, err := bufs.WriteTo(w)
 
+	alloc := b.allocator()
 	for _, buf := range b.bufs {
-		putBuf(buf)
+		alloc.Put(buf)
 	}
-	putBuf(b.toPool)
+	alloc.Put(b.toPool)
 
 	b.bufs = nil
 	b.Buf = nil
@@ -198,6 +337,7 @@ func (b *Buffer) BuildBytes(reuse ...[]byte) []byte {
 
 	var ret []byte
 	size := b.Size()
+	alloc := b.allocator()
 
 	// If we got a buffer as argument and it is big enough, reuse it.
 	if len(reuse) == 1 && cap(reuse[0]) >= size {
@@ -207,11 +347,11 @@ func (b *Buffer) BuildBytes(reuse ...[]byte) []byte {
 	}
 	for _, buf := range b.bufs {
 		ret = append(ret, buf...)
-		putBuf(buf)
+		alloc.Put(buf)
 	}
 
 	ret = append(ret, b.Buf...)
-	putBuf(b.toPool)
+	alloc.Put(b.toPool)
 
 	b.bufs = nil
 	b.toPool = nil
@@ -223,6 +363,7 @@ func (b *Buffer) BuildBytes(reuse ...[]byte) []byte {
 type readCloser struct {
 	offset int
 	bufs   [][]byte
+	alloc  Allocator
 }
 
 func (r *readCloser) Read(p []byte) (n int, err error) {
@@ -238,7 +379,7 @@ func (r *readCloser) Read(p []byte) (n int, err error) {
 			r.bufs = r.bufs[1:]
 
 			// We can release this buffer.
-			putBuf(buf)
+			r.alloc.Put(buf)
 		} else {
 			r.offset += x
 		}
@@ -257,7 +398,7 @@ func (r *readCloser) Read(p []byte) (n int, err error) {
 func (r *readCloser) Close() error {
 	// Release all remaining buffers.
 	for _, buf := range r.bufs {
-		putBuf(buf)
+		r.alloc.Put(buf)
 	}
 	// In case Close gets called multiple times.
 	r.bufs = nil
@@ -267,7 +408,7 @@ func (r *readCloser) Close() error {
 
 // ReadCloser creates an io.ReadCloser with all the contents of the buffer.
 func (b *Buffer) ReadCloser() io.ReadCloser {
-	ret := &readCloser{0, append(b.bufs, b.Buf)}
+	ret := &readCloser{0, append(b.bufs, b.Buf), b.allocator()}
 
 	b.bufs = nil
 	b.toPool = nil
@@ -105,3 +105,98 @@ func TestReadCloser(t *testing.T) {
 		t.Errorf("DumpTo() = %v; want %v", n, len(want))
 	}
 }
+
+func TestSingle(t *testing.T) {
+	dst := make([]byte, 0, 4)
+	dst = append(dst, "abc"...)
+
+	b := Buffer{Buf: dst, Single: true}
+	want := []byte("abc")
+	for i := 0; i < 1000; i++ {
+		b.AppendByte('x')
+		b.AppendString("yz")
+		b.AppendBytes(bytes.Repeat([]byte{'w'}, i%7))
+		want = append(want, 'x', 'y', 'z')
+		want = append(want, bytes.Repeat([]byte{'w'}, i%7)...)
+	}
+	if len(b.bufs) != 0 {
+		t.Errorf("len(bufs) = %d; want 0", len(b.bufs))
+	}
+
+	got := b.BuildBytes()
+	if !bytes.Equal(got, want) {
+		t.Errorf("BuildBytes() = %q; want %q", got, want)
+	}
+	if !bytes.Equal(dst, []byte("abc")) {
+		t.Errorf("dst = %q; want %q", dst, "abc")
+	}
+}
+
+func TestSingleNoCopy(t *testing.T) {
+	dst := make([]byte, 0, 1024)
+	b := Buffer{Buf: dst, Single: true}
+	b.AppendString("test")
+	got := b.BuildBytes()
+	if &got[0] != &dst[:1][0] {
+		t.Error("BuildBytes() copied the data to a new slice")
+	}
+}
+
+func TestPoolConfig(t *testing.T) {
+	p := NewPool(PoolConfig{StartSize: 16, PooledSize: 32, MaxSize: 64})
+	b := Buffer{Alloc: p}
+	b.AppendString("x")
+	if cap(b.Buf) != 16 {
+		t.Errorf("cap(Buf) = %d; want 16", cap(b.Buf))
+	}
+	for i := 0; i < 100; i++ {
+		b.AppendString("abcdefgh")
+	}
+	if cap(b.Buf) != 64 {
+		t.Errorf("cap(Buf) = %d; want 64", cap(b.Buf))
+	}
+	if got := b.BuildBytes(); len(got) != 801 {
+		t.Errorf("len(BuildBytes()) = %d; want 801", len(got))
+	}
+
+	// The default pool is unaffected.
+	if got := DefaultPool().Config(); got != defaultConfig {
+		t.Errorf("DefaultPool().Config() = %+v; want %+v", got, defaultConfig)
+	}
+}
+
+func TestArena(t *testing.T) {
+	a := NewArena(1024)
+	b := Buffer{Alloc: a}
+	b.AppendString("test")
+	if a.Used() != defaultConfig.StartSize {
+		t.Errorf("Used() = %d; want %d", a.Used(), defaultConfig.StartSize)
+	}
+	out := &bytes.Buffer{}
+	if _, err := b.DumpTo(out); err != nil {
+		t.Fatalf("DumpTo() error: %v", err)
+	}
+	if out.String() != "test" {
+		t.Errorf("DumpTo() wrote %q; want %q", out.String(), "test")
+	}
+	if a.Used() != 0 {
+		t.Errorf("Used() = %d after the last chunk was put back; want 0", a.Used())
+	}
+
+	c1 := a.Get(512)
+	c2 := a.Get(512)
+	if cap(c1) != 512 || cap(c2) != 512 || a.Used() != 1024 {
+		t.Errorf("Get() = cap %d, %d, used %d; want 512, 512, 1024", cap(c1), cap(c2), a.Used())
+	}
+	if c3 := a.Get(1); cap(c3) != 1 {
+		t.Errorf("Get() past the block = cap %d; want 1", cap(c3))
+	}
+	a.Put(c1)
+	if a.Used() != 1024 {
+		t.Errorf("Used() = %d after putting back an earlier chunk; want 1024", a.Used())
+	}
+	a.Reset()
+	if a.Used() != 0 {
+		t.Errorf("Used() = %d after Reset(); want 0", a.Used())
+	}
+}
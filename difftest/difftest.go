@@ -0,0 +1,273 @@
+// Package difftest checks that tinyjson encodes and decodes values like encoding/json. It is used by
+// the tests the generator writes with the -gen_tests option, and relies on reflection, so it is
+// not meant to be built with TinyGo.
+package difftest
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+// maxDepth bounds the nesting of the sample values of recursive types.
+const maxDepth = 4
+
+// Case is a sample value of a type.
+type Case struct {
+	Name  string
+	Value interface{} // Pointer to the value.
+}
+
+// sampler is a way of filling sample values.
+type sampler int
+
+const (
+	typical sampler = iota // non-zero values, with characters encoding/json escapes
+	extreme                // smallest and largest integers, empty strings
+	empty                  // empty, but not nil, slices, maps and pointers
+)
+
+var (
+	jsonMarshaler     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler     = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	tinyjsonMarshaler = reflect.TypeOf((*tinyjson.Marshaler)(nil)).Elem()
+)
+
+// Cases returns sample values of the type v points to: its zero value, values with all exported
+// fields set to typical and to extreme values, and a value with empty slices and maps. Nested
+// values of types implementing marshalers, other than structs, are left zero, as they may have
+// to be valid, e.g. tinyjson.Addr or tinyjson.RawMessage.
+func Cases(v interface{}) []Case {
+	t := reflect.TypeOf(v).Elem()
+	cases := []Case{{Name: "zero", Value: reflect.New(t).Interface()}}
+	for _, c := range []struct {
+		name string
+		s    sampler
+	}{{"typical", typical}, {"extreme", extreme}, {"empty", empty}} {
+		p := reflect.New(t)
+		fill(p.Elem(), c.s, 0)
+		cases = append(cases, Case{Name: c.name, Value: p.Interface()})
+	}
+	return cases
+}
+
+// hasMarshaler reports whether values of type t are encoded by methods of their own.
+func hasMarshaler(t reflect.Type) bool {
+	for _, iface := range []reflect.Type{jsonMarshaler, textMarshaler, tinyjsonMarshaler} {
+		if t.Implements(iface) || reflect.PtrTo(t).Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// fill sets v to a sample value.
+func fill(v reflect.Value, s sampler, depth int) {
+	t := v.Type()
+	if depth > maxDepth || (depth > 0 && t.Kind() != reflect.Struct && hasMarshaler(t)) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(s == typical)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch s {
+		case typical:
+			v.SetInt(-42)
+		case extreme:
+			v.SetInt(math.MinInt64 >> (64 - t.Bits()))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch s {
+		case typical:
+			v.SetUint(42)
+		case extreme:
+			v.SetUint(math.MaxUint64 >> (64 - t.Bits()))
+		}
+	case reflect.Float32, reflect.Float64:
+		switch s {
+		case typical:
+			v.SetFloat(1.5)
+		case extreme:
+			v.SetFloat(-1e20)
+		}
+	case reflect.String:
+		if s == typical {
+			v.SetString("<a href=\"x\">& é</a>\t\x01")
+		}
+	case reflect.Ptr:
+		e := reflect.New(t.Elem())
+		if s != empty {
+			fill(e.Elem(), s, depth+1)
+		}
+		v.Set(e)
+	case reflect.Slice:
+		n := 0
+		if s == typical {
+			n = 2
+		}
+		v.Set(reflect.MakeSlice(t, n, n))
+		for i := 0; i < n; i++ {
+			fill(v.Index(i), s, depth+1)
+		}
+	case reflect.Array:
+		if s != empty {
+			for i := 0; i < v.Len(); i++ {
+				fill(v.Index(i), s, depth+1)
+			}
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(t))
+		if s == typical {
+			key := reflect.New(t.Key()).Elem()
+			fill(key, s, depth+1)
+			if key.Kind() == reflect.String && key.Len() == 0 && !hasMarshaler(t.Key()) {
+				key.SetString("key")
+			}
+			elem := reflect.New(t.Elem()).Elem()
+			fill(elem, s, depth+1)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				fill(f, s, depth+1)
+			}
+		}
+	}
+}
+
+// Check checks that the tinyjson encoding of v, a pointer to a value of a generated type, decodes
+// to a value with the same encoding. If std is not nil, it also checks that the encoding is
+// equivalent to the encoding/json encoding of std, the same value converted to a type without
+// the generated methods, and that tinyjson decodes the latter to the same value.
+func Check(t testing.TB, v interface{}, std interface{}) {
+	t.Helper()
+	data, err := tinyjson.Marshal(v.(tinyjson.Marshaler))
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() error: %v", err)
+	}
+	checkDecode(t, "tinyjson", v, data, data)
+
+	if std == nil {
+		return
+	}
+	stdData, err := json.Marshal(std)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if !Equal(data, stdData) {
+		t.Fatalf("tinyjson encoding differs from encoding/json:\ntinyjson:      %s\nencoding/json: %s", data, stdData)
+	}
+	checkDecode(t, "encoding/json", v, stdData, data)
+}
+
+// checkDecode checks that data, encoded by the given encoder, decodes to a new value of the type
+// v points to with the tinyjson encoding want.
+func checkDecode(t testing.TB, encoder string, v interface{}, data, want []byte) {
+	t.Helper()
+	decoded := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+	if err := tinyjson.Unmarshal(data, decoded.(tinyjson.Unmarshaler)); err != nil {
+		t.Fatalf("tinyjson.Unmarshal() of the %s encoding %s error: %v", encoder, data, err)
+	}
+	got, err := tinyjson.Marshal(decoded.(tinyjson.Marshaler))
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() of the decoded value error: %v", err)
+	}
+	if !Equal(got, want) {
+		t.Fatalf("the %s encoding does not round-trip:\ngot:  %s\nwant: %s", encoder, got, want)
+	}
+}
+
+// Seed adds the tinyjson encodings of the sample values of the type v points to to the seed
+// corpus of f.
+func Seed(f *testing.F, v interface{}) {
+	for _, c := range Cases(v) {
+		data, err := tinyjson.Marshal(c.Value.(tinyjson.Marshaler))
+		if err == nil {
+			f.Add(data)
+		}
+	}
+}
+
+// Equal reports whether a and b are equivalent JSON documents: members may be in another order,
+// strings escaped differently and floating-point numbers formatted differently.
+func Equal(a, b []byte) bool {
+	va, err := decode(a)
+	if err != nil {
+		return false
+	}
+	vb, err := decode(b)
+	if err != nil {
+		return false
+	}
+	return equalValues(va, vb)
+}
+
+// decode decodes the JSON document data, keeping numbers as written.
+func decode(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// equalValues reports whether the decoded JSON values a and b are equivalent.
+func equalValues(a, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, va := range a {
+			vb, ok := b[k]
+			if !ok || !equalValues(va, vb) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalValues(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if a == b {
+			return true
+		}
+		// Integers are compared exactly, other numbers as floats, e.g. 1e2 and 100.0.
+		if !strings.ContainsAny(string(a), ".eE") && !strings.ContainsAny(string(b), ".eE") {
+			return false
+		}
+		fa, err := strconv.ParseFloat(string(a), 64)
+		if err != nil {
+			return false
+		}
+		fb, err := strconv.ParseFloat(string(b), 64)
+		return err == nil && fa == fb
+	default:
+		return a == b
+	}
+}
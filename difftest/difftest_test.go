@@ -0,0 +1,58 @@
+package difftest
+
+import (
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want bool
+	}{
+		{`{"a":1,"b":[true,null]}`, `{"b":[true,null],"a":1}`, true},
+		{`"<a>"`, `"<a>"`, true},
+		{`100.0`, `1e2`, true},
+		{`100`, `1e2`, true},
+		{`18446744073709551615`, `18446744073709551615`, true},
+		{`18446744073709551615`, `18446744073709551614`, false},
+		{`{"a":1}`, `{"a":1,"b":2}`, false},
+		{`[1,2]`, `[2,1]`, false},
+		{`""`, `null`, false},
+		{`{"a":1}`, `{"a":1`, false},
+	} {
+		if got := Equal([]byte(test.a), []byte(test.b)); got != test.want {
+			t.Errorf("Equal(%s, %s) = %v; want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+type sample struct {
+	Name   string
+	Count  int8
+	Values []uint16
+	Labels map[string]bool
+	Next   *sample
+	hidden int
+}
+
+func TestCases(t *testing.T) {
+	cases := map[string]*sample{}
+	for _, c := range Cases(new(sample)) {
+		cases[c.Name] = c.Value.(*sample)
+	}
+
+	if v := cases["zero"]; v.Values != nil || v.Labels != nil || v.Next != nil {
+		t.Errorf("zero case = %+v; want the zero value", v)
+	}
+	if v := cases["typical"]; v.Name == "" || v.Count != -42 || len(v.Values) != 2 || len(v.Labels) != 1 ||
+		v.Next == nil || v.Next.Name == "" {
+		t.Errorf("typical case = %+v; want all fields set", v)
+	}
+	if v := cases["extreme"]; v.Count != -128 || v.Values != nil && len(v.Values) != 0 || v.Next.Next == nil {
+		t.Errorf("extreme case = %+v; want extreme integers", v)
+	}
+	if v := cases["empty"]; v.Values == nil || len(v.Values) != 0 || v.Labels == nil || v.Next == nil ||
+		v.Next.Values != nil || v.hidden != 0 {
+		t.Errorf("empty case = %+v; want empty slices and maps", v)
+	}
+}
@@ -0,0 +1,15 @@
+package tinyjson
+
+import "github.com/CosmWasm/tinyjson/jlexer"
+
+// Kinds of decoding errors, wrapped by the returned *jlexer.LexerError and meant to be checked
+// with errors.Is. See the jlexer package for their descriptions.
+var (
+	ErrSyntax        = jlexer.ErrSyntax
+	ErrTypeMismatch  = jlexer.ErrTypeMismatch
+	ErrOverflow      = jlexer.ErrOverflow
+	ErrInvalidValue  = jlexer.ErrInvalidValue
+	ErrTrailingData  = jlexer.ErrTrailingData
+	ErrUnknownField  = jlexer.ErrUnknownField
+	ErrRequiredField = jlexer.ErrRequiredField
+)
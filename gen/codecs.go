@@ -0,0 +1,104 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// codec is the code encoding and decoding values of a type, registered with RegisterCodec.
+type codec struct {
+	encoder, decoder string
+}
+
+// RegisterCodec registers the encoder and decoder of the type with the given fully qualified
+// name, e.g. "math/big.Int" or "time.Duration", so that types one does not own get codecs
+// without being wrapped. They are used wherever the type appears, including as slice elements
+// and map keys, and take precedence over the marshaler interfaces the type implements.
+//
+// The encoder and decoder are either names of functions, qualified with the import path of
+// their package unless declared in the generated package, e.g. "example.com/bigjson.Encode",
+// called as
+//
+//	func Encode(out *jwriter.Writer, v *big.Int)
+//	func Decode(in *jlexer.Lexer, v *big.Int)
+//
+// or snippets of code, in which %v stands for the value, encoding it with the jwriter.Writer
+// out, e.g. "out.String(%v.String())", or decoding it with the jlexer.Lexer in, e.g.
+// "%v = parseDuration(in)". Snippets can only refer to the jwriter, jlexer and tinyjson packages
+// and to the generated package. Values of map keys must be encoded as strings.
+func (g *Generator) RegisterCodec(typeName, encoder, decoder string) {
+	g.codecs[typeName] = codec{encoder: encoder, decoder: decoder}
+}
+
+// qualifiedTypeName returns the name a codec is registered for the type with.
+func qualifiedTypeName(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// hasCodec reports whether a codec is registered for the type t.
+func (g *Generator) hasCodec(t reflect.Type) bool {
+	_, ok := g.codecs[qualifiedTypeName(t)]
+	return ok
+}
+
+// codecFunc returns the expression calling the codec function with the given name.
+func (g *Generator) codecFunc(name string) string {
+	i := strings.LastIndexByte(name, '.')
+	if i == -1 {
+		return name
+	}
+	if pkgPath := name[:i]; pkgPath != g.pkgPath {
+		return g.pkgAlias(pkgPath) + name[i:]
+	}
+	return name[i+1:]
+}
+
+// operand returns the expression as an operand of a snippet, in parentheses if dereferencing.
+func operand(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return "(" + expr + ")"
+	}
+	return expr
+}
+
+// genCodecEncoder generates code encoding in with the codec registered for the type t, if any.
+func (g *Generator) genCodecEncoder(t reflect.Type, in string, indent int) (bool, error) {
+	c, ok := g.codecs[qualifiedTypeName(t)]
+	if !ok {
+		return false, nil
+	}
+	if c.encoder == "" {
+		return false, fmt.Errorf("no encoder registered for %v", qualifiedTypeName(t))
+	}
+
+	ws := strings.Repeat("  ", indent)
+	if strings.Contains(c.encoder, "%v") {
+		fmt.Fprintln(g.out, ws+strings.Replace(c.encoder, "%v", operand(in), -1))
+	} else {
+		fmt.Fprintln(g.out, ws+g.codecFunc(c.encoder)+"(out, "+addressOf(in)+")")
+	}
+	return true, nil
+}
+
+// genCodecDecoder generates code decoding out with the codec registered for the type t, if any.
+func (g *Generator) genCodecDecoder(t reflect.Type, out string, indent int) (bool, error) {
+	c, ok := g.codecs[qualifiedTypeName(t)]
+	if !ok {
+		return false, nil
+	}
+	if c.decoder == "" {
+		return false, fmt.Errorf("no decoder registered for %v", qualifiedTypeName(t))
+	}
+
+	ws := strings.Repeat("  ", indent)
+	if strings.Contains(c.decoder, "%v") {
+		fmt.Fprintln(g.out, ws+strings.Replace(c.decoder, "%v", operand(out), -1))
+	} else {
+		fmt.Fprintln(g.out, ws+g.codecFunc(c.decoder)+"(in, "+addressOf(out)+")")
+	}
+	return true, nil
+}
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/CosmWasm/tinyjson"
 )
@@ -53,12 +56,23 @@ var primitiveStringDecoders = map[reflect.Kind]string{
 	reflect.Float64: "in.Float64Str()",
 }
 
-var customDecoders = map[string]string{}
-
 // genTypeDecoder generates decoding code for the type t, but uses unmarshaler interface if implemented by t.
 func (g *Generator) genTypeDecoder(t reflect.Type, out string, tags fieldTags, indent int) error {
 	ws := strings.Repeat("  ", indent)
 
+	if ok, err := g.genCodecDecoder(t, out, indent); ok || err != nil {
+		return err
+	}
+	if g.genExternalDecoder(t, out, indent) {
+		return nil
+	}
+
+	if g.proto3JSON {
+		if ok := g.genProto3Decoder(t, out, tags, indent); ok {
+			return nil
+		}
+	}
+
 	unmarshalerIface := reflect.TypeOf((*tinyjson.Unmarshaler)(nil)).Elem()
 	if reflect.PtrTo(t).Implements(unmarshalerIface) {
 		fmt.Fprintln(g.out, ws+"("+out+").UnmarshalTinyJSON(in)")
@@ -68,7 +82,7 @@ func (g *Generator) genTypeDecoder(t reflect.Type, out string, tags fieldTags, i
 	unmarshalerIface = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
 	if reflect.PtrTo(t).Implements(unmarshalerIface) {
 		fmt.Fprintln(g.out, ws+"if data := in.Raw(); in.Ok() {")
-		fmt.Fprintln(g.out, ws+"  in.AddError( ("+out+").UnmarshalJSON(data) )")
+		fmt.Fprintln(g.out, ws+"  in.AddNonFatalError( ("+out+").UnmarshalJSON(data) )")
 		fmt.Fprintln(g.out, ws+"}")
 		return nil
 	}
@@ -76,7 +90,7 @@ func (g *Generator) genTypeDecoder(t reflect.Type, out string, tags fieldTags, i
 	unmarshalerIface = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 	if reflect.PtrTo(t).Implements(unmarshalerIface) {
 		fmt.Fprintln(g.out, ws+"if data := in.UnsafeBytes(); in.Ok() {")
-		fmt.Fprintln(g.out, ws+"  in.AddError( ("+out+").UnmarshalText(data) )")
+		fmt.Fprintln(g.out, ws+"  in.AddNonFatalError( ("+out+").UnmarshalText(data) )")
 		fmt.Fprintln(g.out, ws+"}")
 		return nil
 	}
@@ -106,9 +120,22 @@ func hasUnknownsMarshaler(t reflect.Type) bool {
 // genTypeDecoderNoCheck generates decoding code for the type t.
 func (g *Generator) genTypeDecoderNoCheck(t reflect.Type, out string, tags fieldTags, indent int) error {
 	ws := strings.Repeat("  ", indent)
+
+	if typeParamIndex(t) >= 0 {
+		if err := g.checkTypeParamTags(t, tags); err != nil {
+			return err
+		}
+		g.genTypeParamDecoder(out, indent)
+		return nil
+	}
 	// Check whether type is primitive, needs to be done after interface check.
-	if dec := customDecoders[t.String()]; dec != "" {
-		fmt.Fprintln(g.out, ws+out+" = "+dec)
+	if dec := primitiveStringDecoders[t.Kind()]; dec != "" && g.proto3JSON && t.Kind() != reflect.String && primitiveDecoders[t.Kind()] != "" {
+		// proto3 JSON accepts integers both as numbers and as strings.
+		fmt.Fprintln(g.out, ws+"if in.IsString() {")
+		fmt.Fprintln(g.out, ws+"  "+out+" = "+g.getType(t)+"("+dec+")")
+		fmt.Fprintln(g.out, ws+"} else {")
+		fmt.Fprintln(g.out, ws+"  "+out+" = "+g.getType(t)+"("+primitiveDecoders[t.Kind()]+")")
+		fmt.Fprintln(g.out, ws+"}")
 		return nil
 	} else if dec := primitiveStringDecoders[t.Kind()]; dec != "" && tags.asString {
 		if tags.intern && t.Kind() == reflect.String {
@@ -137,7 +164,11 @@ func (g *Generator) genTypeDecoderNoCheck(t reflect.Type, out string, tags field
 			fmt.Fprintln(g.out, ws+"  in.Skip()")
 			fmt.Fprintln(g.out, ws+"  "+out+" = nil")
 			fmt.Fprintln(g.out, ws+"} else {")
-			if g.simpleBytes {
+			if tags.hex {
+				fmt.Fprintln(g.out, ws+"  "+out+" = in.HexBytes()")
+			} else if tags.base64 {
+				fmt.Fprintln(g.out, ws+"  "+out+" = in.Base64Bytes()")
+			} else if g.simpleBytes {
 				fmt.Fprintln(g.out, ws+"  "+out+" = []byte(in.String())")
 			} else {
 				fmt.Fprintln(g.out, ws+"  "+out+" = in.Bytes()")
@@ -188,7 +219,13 @@ func (g *Generator) genTypeDecoderNoCheck(t reflect.Type, out string, tags field
 			fmt.Fprintln(g.out, ws+"if in.IsNull() {")
 			fmt.Fprintln(g.out, ws+"  in.Skip()")
 			fmt.Fprintln(g.out, ws+"} else {")
-			fmt.Fprintln(g.out, ws+"  copy("+out+"[:], in.Bytes())")
+			if tags.hex {
+				fmt.Fprintln(g.out, ws+"  in.HexArray(("+out+")[:])")
+			} else if tags.base64 {
+				fmt.Fprintln(g.out, ws+"  in.Base64Array(("+out+")[:])")
+			} else {
+				fmt.Fprintln(g.out, ws+"  copy("+out+"[:], in.Bytes())")
+			}
 			fmt.Fprintln(g.out, ws+"}")
 
 		} else {
@@ -246,7 +283,7 @@ func (g *Generator) genTypeDecoderNoCheck(t reflect.Type, out string, tags field
 	case reflect.Map:
 		key := t.Key()
 		keyDec, ok := primitiveStringDecoders[key.Kind()]
-		if !ok && !hasCustomUnmarshaler(key) {
+		if !ok && !hasCustomUnmarshaler(key) && !g.hasCodec(key) {
 			return fmt.Errorf("map type %v not supported: only string and integer keys and types implementing json.Unmarshaler are allowed", key)
 		} // else assume the caller knows what they are doing and that the custom unmarshaler performs the translation from string or integer keys to the key type
 		elem := t.Elem()
@@ -257,22 +294,26 @@ func (g *Generator) genTypeDecoderNoCheck(t reflect.Type, out string, tags field
 		fmt.Fprintln(g.out, ws+"  in.Skip()")
 		fmt.Fprintln(g.out, ws+"} else {")
 		fmt.Fprintln(g.out, ws+"  in.Delim('{')")
-		if !keepEmpty {
-			fmt.Fprintln(g.out, ws+"  if !in.IsDelim('}') {")
-		}
-		fmt.Fprintln(g.out, ws+"  "+out+" = make("+g.getType(t)+")")
-		if !keepEmpty {
-			fmt.Fprintln(g.out, ws+"  } else {")
-			fmt.Fprintln(g.out, ws+"  "+out+" = nil")
-			fmt.Fprintln(g.out, ws+"  }")
+		// Members are merged into an existing map.
+		if keepEmpty {
+			fmt.Fprintln(g.out, ws+"  if "+out+" == nil {")
+		} else {
+			fmt.Fprintln(g.out, ws+"  if "+out+" == nil && !in.IsDelim('}') {")
 		}
+		fmt.Fprintln(g.out, ws+"    "+out+" = make("+g.getType(t)+")")
+		fmt.Fprintln(g.out, ws+"  }")
 
 		fmt.Fprintln(g.out, ws+"  for !in.IsDelim('}') {")
 		// NOTE: extra check for TextUnmarshaler. It overrides default methods.
-		if reflect.PtrTo(key).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
+		if g.hasCodec(key) {
+			fmt.Fprintln(g.out, ws+"    var key "+g.getType(key))
+			if err := g.genTypeDecoder(key, "key", tags, indent+2); err != nil {
+				return err
+			}
+		} else if reflect.PtrTo(key).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
 			fmt.Fprintln(g.out, ws+"    var key "+g.getType(key))
 			fmt.Fprintln(g.out, ws+"if data := in.UnsafeBytes(); in.Ok() {")
-			fmt.Fprintln(g.out, ws+"  in.AddError(key.UnmarshalText(data) )")
+			fmt.Fprintln(g.out, ws+"  in.AddNonFatalError(key.UnmarshalText(data) )")
 			fmt.Fprintln(g.out, ws+"}")
 		} else if keyDec != "" {
 			fmt.Fprintln(g.out, ws+"    key := "+g.getType(key)+"("+keyDec+")")
@@ -314,7 +355,14 @@ func (g *Generator) genTypeDecoderNoCheck(t reflect.Type, out string, tags field
 			fmt.Fprintln(g.out, ws+"} else if m, ok := "+out+".(json.Unmarshaler); ok {")
 			fmt.Fprintln(g.out, ws+"_ = m.UnmarshalJSON(in.Raw())")
 			fmt.Fprintln(g.out, ws+"} else {")
-			fmt.Fprintln(g.out, ws+"  "+out+" = in.Interface()")
+			if g.interfaceValue {
+				tmpVar := g.uniqueVarName()
+				fmt.Fprintln(g.out, ws+"  var "+tmpVar+" tinyjson.Value")
+				fmt.Fprintln(g.out, ws+"  "+tmpVar+".UnmarshalTinyJSON(in)")
+				fmt.Fprintln(g.out, ws+"  "+out+" = "+tmpVar)
+			} else {
+				fmt.Fprintln(g.out, ws+"  "+out+" = in.Interface()")
+			}
 			fmt.Fprintln(g.out, ws+"}")
 		}
 	default:
@@ -332,8 +380,7 @@ func (g *Generator) interfaceIsJsonUnmarshaller(t reflect.Type) bool {
 	return t.Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem())
 }
 
-func (g *Generator) genStructFieldDecoder(t reflect.Type, f reflect.StructField) error {
-	jsonName := g.fieldNamer.GetJSONFieldName(t, f)
+func (g *Generator) genStructFieldDecoder(t reflect.Type, f reflect.StructField, index int, reused string) error {
 	tags := parseFieldTags(f)
 
 	if tags.omit {
@@ -343,7 +390,13 @@ func (g *Generator) genStructFieldDecoder(t reflect.Type, f reflect.StructField)
 		return errors.New("Mutually exclusive tags are specified: 'intern' and 'nocopy'")
 	}
 
-	fmt.Fprintf(g.out, "    case %q:\n", jsonName)
+	fmt.Fprintf(g.out, "    case %d:\n", index)
+	if reused != "" {
+		// Decode into the backing array the field had before the struct was reset.
+		fmt.Fprintln(g.out, "      if "+reused+" != nil {")
+		fmt.Fprintln(g.out, "        out."+f.Name+" = "+reused)
+		fmt.Fprintln(g.out, "      }")
+	}
 	if err := g.genTypeDecoder(f.Type, "out."+f.Name, tags, 3); err != nil {
 		return err
 	}
@@ -366,20 +419,119 @@ func (g *Generator) genRequiredFieldSet(t reflect.Type, f reflect.StructField) {
 }
 
 func (g *Generator) genRequiredFieldCheck(t reflect.Type, f reflect.StructField) {
-	jsonName := g.fieldNamer.GetJSONFieldName(t, f)
+	jsonName := g.jsonFieldName(t, f)
 	tags := parseFieldTags(f)
 
 	if !tags.required {
 		return
 	}
 
-	g.imports["fmt"] = "fmt"
-
 	fmt.Fprintf(g.out, "if !%sSet {\n", f.Name)
-	fmt.Fprintf(g.out, "    in.AddError(fmt.Errorf(\"key '%s' is required\"))\n", jsonName)
+	fmt.Fprintf(g.out, "    in.AddNonFatalError(&jlexer.LexerError{\n")
+	fmt.Fprintf(g.out, "        Offset: in.GetPos(),\n")
+	fmt.Fprintf(g.out, "        Reason: \"key '%s' is required\",\n", jsonName)
+	fmt.Fprintf(g.out, "        Err:    jlexer.ErrRequiredField,\n")
+	fmt.Fprintf(g.out, "    })\n")
 	fmt.Fprintf(g.out, "}\n")
 }
 
+// fieldName is a member name matched to a struct field by decoders.
+type fieldName struct {
+	name  string
+	index int
+}
+
+// genFieldDispatch generates code setting field to the index of the field whose name matches
+// key, the member name as a byte slice. Names are told apart by their length, then by the byte
+// at the position that differs the most among the names of a length, so that key is usually
+// compared to a single name.
+func (g *Generator) genFieldDispatch(names []fieldName, indent int) {
+	ws := strings.Repeat("  ", indent)
+
+	byLen := map[int][]fieldName{}
+	var lens []int
+	for _, n := range names {
+		if byLen[len(n.name)] == nil {
+			lens = append(lens, len(n.name))
+		}
+		byLen[len(n.name)] = append(byLen[len(n.name)], n)
+	}
+	if len(lens) == 0 {
+		return
+	}
+	sort.Ints(lens)
+
+	fmt.Fprintln(g.out, ws+"switch len(key) {")
+	for _, l := range lens {
+		group := byLen[l]
+		fmt.Fprintf(g.out, ws+"case %d:\n", l)
+		if len(group) == 1 {
+			g.genFieldNameMatch(group, indent+1)
+			continue
+		}
+
+		pos := discriminatingByte(group)
+		byByte := map[byte][]fieldName{}
+		var bytes []int
+		for _, n := range group {
+			c := n.name[pos]
+			if byByte[c] == nil {
+				bytes = append(bytes, int(c))
+			}
+			byByte[c] = append(byByte[c], n)
+		}
+		sort.Ints(bytes)
+
+		fmt.Fprintf(g.out, ws+"  switch key[%d] {\n", pos)
+		for _, c := range bytes {
+			fmt.Fprintf(g.out, ws+"  case %s:\n", byteLiteral(byte(c)))
+			g.genFieldNameMatch(byByte[byte(c)], indent+2)
+		}
+		fmt.Fprintln(g.out, ws+"  }")
+	}
+	fmt.Fprintln(g.out, ws+"}")
+}
+
+// genFieldNameMatch generates the comparisons of key with the names.
+func (g *Generator) genFieldNameMatch(names []fieldName, indent int) {
+	ws := strings.Repeat("  ", indent)
+	for i, n := range names {
+		if i > 0 {
+			fmt.Fprint(g.out, " else ")
+		} else {
+			fmt.Fprint(g.out, ws)
+		}
+		fmt.Fprintf(g.out, "if string(key) == %s {\n", strconv.Quote(n.name))
+		fmt.Fprintf(g.out, ws+"  field = %d\n", n.index)
+		fmt.Fprint(g.out, ws+"}")
+	}
+	fmt.Fprintln(g.out)
+}
+
+// discriminatingByte returns the position of the byte taking the most distinct values among
+// names of the same length.
+func discriminatingByte(names []fieldName) int {
+	best, bestCount := 0, 0
+	for pos := 0; pos < len(names[0].name); pos++ {
+		seen := map[byte]bool{}
+		for _, n := range names {
+			seen[n.name[pos]] = true
+		}
+		if len(seen) > bestCount {
+			best, bestCount = pos, len(seen)
+		}
+	}
+	return best
+}
+
+// byteLiteral returns a Go literal for the byte c.
+func byteLiteral(c byte) string {
+	if c < utf8.RuneSelf && unicode.IsPrint(rune(c)) {
+		return strconv.QuoteRune(rune(c))
+	}
+	return fmt.Sprintf("0x%02x", c)
+}
+
 func mergeStructFields(fields1, fields2 []reflect.StructField) (fields []reflect.StructField) {
 	used := map[string]bool{}
 	for _, f := range fields2 {
@@ -461,8 +613,22 @@ func (g *Generator) genSliceArrayDecoder(t reflect.Type) error {
 	fname := g.getDecoderName(t)
 	typ := g.getType(t)
 
-	fmt.Fprintln(g.out, "func "+fname+"(in *jlexer.Lexer, out *"+typ+") {")
+	fmt.Fprintln(g.out, "func "+fname+g.typeParamList(t)+"(in *jlexer.Lexer, out *"+typ+") {")
 	fmt.Fprintln(g.out, " isTopLevel := in.IsStart()")
+	if t.Kind() != reflect.Slice {
+		// Slices are always replaced, maps and arrays are only reset on request.
+		reset := "  *out = " + typ + "{}"
+		if t.Kind() == reflect.Map {
+			reset = "  *out = nil"
+		}
+		if g.resetOnDecode {
+			fmt.Fprintln(g.out, reset)
+		} else {
+			fmt.Fprintln(g.out, "  if in.ResetOnDecode {")
+			fmt.Fprintln(g.out, "  "+reset)
+			fmt.Fprintln(g.out, "  }")
+		}
+	}
 	err := g.genTypeDecoderNoCheck(t, "*out", fieldTags{}, 1)
 	if err != nil {
 		return err
@@ -483,7 +649,7 @@ func (g *Generator) genStructDecoder(t reflect.Type) error {
 	fname := g.getDecoderName(t)
 	typ := g.getType(t)
 
-	fmt.Fprintln(g.out, "func "+fname+"(in *jlexer.Lexer, out *"+typ+") {")
+	fmt.Fprintln(g.out, "func "+fname+g.typeParamList(t)+"(in *jlexer.Lexer, out *"+typ+") {")
 	fmt.Fprintln(g.out, "  isTopLevel := in.IsStart()")
 	fmt.Fprintln(g.out, "  if in.IsNull() {")
 	fmt.Fprintln(g.out, "    if isTopLevel {")
@@ -493,27 +659,48 @@ func (g *Generator) genStructDecoder(t reflect.Type) error {
 	fmt.Fprintln(g.out, "    return")
 	fmt.Fprintln(g.out, "  }")
 
+	fs, err := getStructFields(t)
+	if err != nil {
+		return fmt.Errorf("cannot generate decoder for %v: %v", t, err)
+	}
+	reused := g.genStructReset(t, fs)
+
 	// Init embedded pointer fields.
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
 		if !f.Anonymous || f.Type.Kind() != reflect.Ptr {
 			continue
 		}
-		fmt.Fprintln(g.out, "  out."+f.Name+" = new("+g.getType(f.Type.Elem())+")")
+		fmt.Fprintln(g.out, "  if out."+f.Name+" == nil {")
+		fmt.Fprintln(g.out, "    out."+f.Name+" = new("+g.getType(f.Type.Elem())+")")
+		fmt.Fprintln(g.out, "  }")
 	}
 
-	fs, err := getStructFields(t)
-	if err != nil {
-		return fmt.Errorf("cannot generate decoder for %v: %v", t, err)
+	for _, f := range fs {
+		g.genRequiredFieldSet(t, f)
 	}
 
+	// Member names are matched to the index of their field, which selects the decoder.
+	var names []fieldName
+	index := 0
 	for _, f := range fs {
-		g.genRequiredFieldSet(t, f)
+		if parseFieldTags(f).omit {
+			continue
+		}
+		for _, name := range g.jsonFieldNames(t, f) {
+			names = append(names, fieldName{name: name, index: index})
+		}
+		index++
 	}
 
+	unknownsUnmarshaler := hasUnknownsUnmarshaler(t)
 	fmt.Fprintln(g.out, "  in.Delim('{')")
 	fmt.Fprintln(g.out, "  for !in.IsDelim('}') {")
-	fmt.Fprintf(g.out, "    key := in.UnsafeFieldName(%v)\n", g.skipMemberNameUnescaping)
+	if len(names) > 0 || g.disallowUnknownFields || unknownsUnmarshaler {
+		fmt.Fprintf(g.out, "    key := in.UnsafeFieldNameBytes(%v)\n", g.skipMemberNameUnescaping)
+	} else {
+		fmt.Fprintf(g.out, "    in.UnsafeFieldNameBytes(%v)\n", g.skipMemberNameUnescaping)
+	}
 	fmt.Fprintln(g.out, "    in.WantColon()")
 	fmt.Fprintln(g.out, "    if in.IsNull() {")
 	fmt.Fprintln(g.out, "       in.Skip()")
@@ -521,22 +708,32 @@ func (g *Generator) genStructDecoder(t reflect.Type) error {
 	fmt.Fprintln(g.out, "       continue")
 	fmt.Fprintln(g.out, "    }")
 
-	fmt.Fprintln(g.out, "    switch key {")
+	fmt.Fprintln(g.out, "    field := -1")
+	g.genFieldDispatch(names, 2)
+
+	fmt.Fprintln(g.out, "    switch field {")
+	index = 0
 	for _, f := range fs {
-		if err := g.genStructFieldDecoder(t, f); err != nil {
+		if parseFieldTags(f).omit {
+			continue
+		}
+		if err := g.genStructFieldDecoder(t, f, index, reused[f.Name]); err != nil {
 			return err
 		}
+		index++
 	}
 
 	fmt.Fprintln(g.out, "    default:")
 	if g.disallowUnknownFields {
-		fmt.Fprintln(g.out, `      in.AddError(&jlexer.LexerError{
-          Offset: in.GetPos(),
+		fmt.Fprintln(g.out, `      in.AddNonFatalError(&jlexer.LexerError{
+          Offset: in.TokenStart(),
           Reason: "unknown field",
-          Data: key,
-      })`)
-	} else if hasUnknownsUnmarshaler(t) {
-		fmt.Fprintln(g.out, "      out.UnmarshalUnknown(in, key)")
+          Err: jlexer.ErrUnknownField,
+          Data: string(key),
+      })
+      in.SkipRecursive()`)
+	} else if unknownsUnmarshaler {
+		fmt.Fprintln(g.out, "      out.UnmarshalUnknown(in, string(key))")
 	} else {
 		fmt.Fprintln(g.out, "      in.SkipRecursive()")
 	}
@@ -544,19 +741,64 @@ func (g *Generator) genStructDecoder(t reflect.Type) error {
 	fmt.Fprintln(g.out, "    in.WantComma()")
 	fmt.Fprintln(g.out, "  }")
 	fmt.Fprintln(g.out, "  in.Delim('}')")
-	fmt.Fprintln(g.out, "  if isTopLevel {")
-	fmt.Fprintln(g.out, "    in.Consumed()")
-	fmt.Fprintln(g.out, "  }")
 
 	for _, f := range fs {
 		g.genRequiredFieldCheck(t, f)
 	}
 
+	fmt.Fprintln(g.out, "  if isTopLevel {")
+	fmt.Fprintln(g.out, "    in.Consumed()")
+	fmt.Fprintln(g.out, "  }")
+
 	fmt.Fprintln(g.out, "}")
 
 	return nil
 }
 
+// genStructReset generates the code resetting the decoded struct to its zero value, always if
+// the ResetOnDecode option is set and otherwise if the lexer's ResetOnDecode flag is. The slice
+// fields of the struct are saved so that their capacity is reused; it returns the names of the
+// variables holding them by field name.
+func (g *Generator) genStructReset(t reflect.Type, fs []reflect.StructField) map[string]string {
+	reused := map[string]string{}
+	var saved []reflect.StructField
+	for _, f := range fs {
+		if len(f.Index) == 1 && g.reusesSliceCapacity(f.Type) && !parseFieldTags(f).omit {
+			reused[f.Name] = g.uniqueVarName()
+			saved = append(saved, f)
+		}
+	}
+
+	if g.resetOnDecode {
+		for _, f := range saved {
+			fmt.Fprintln(g.out, "  "+reused[f.Name]+" := out."+f.Name)
+		}
+		fmt.Fprintln(g.out, "  *out = "+g.getType(t)+"{}")
+		return reused
+	}
+
+	for _, f := range saved {
+		fmt.Fprintln(g.out, "  var "+reused[f.Name]+" "+g.getType(f.Type))
+	}
+	fmt.Fprintln(g.out, "  if in.ResetOnDecode {")
+	for _, f := range saved {
+		fmt.Fprintln(g.out, "    "+reused[f.Name]+" = out."+f.Name)
+	}
+	fmt.Fprintln(g.out, "    *out = "+g.getType(t)+"{}")
+	fmt.Fprintln(g.out, "  }")
+	return reused
+}
+
+// reusesSliceCapacity reports whether decoding a value of type t appends to the existing slice,
+// truncated to zero length, rather than allocating a new one.
+func (g *Generator) reusesSliceCapacity(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice || hasCustomUnmarshaler(t) || g.hasCodec(t) {
+		return false
+	}
+	elem := t.Elem()
+	return elem.Kind() != reflect.Uint8 || elem.Name() != "uint8"
+}
+
 func (g *Generator) genStructUnmarshaler(t reflect.Type) error {
 	switch t.Kind() {
 	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
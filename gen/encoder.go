@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/CosmWasm/tinyjson"
 )
@@ -61,6 +64,9 @@ type fieldTags struct {
 	intern          bool
 	noCopy          bool
 	nilSliceAsEmpty bool
+	protoEnum       bool
+	hex             bool
+	base64          bool
 }
 
 // parseFieldTags parses the json field tag into a structure.
@@ -87,6 +93,16 @@ func parseFieldTags(f reflect.StructField) fieldTags {
 			ret.noCopy = true
 		case s == "emptyslice":
 			ret.nilSliceAsEmpty = true
+		case s == "hex":
+			ret.hex = true
+		case s == "base64":
+			ret.base64 = true
+		}
+	}
+
+	for _, s := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(s, "enum=") {
+			ret.protoEnum = true
 		}
 	}
 
@@ -97,6 +113,19 @@ func parseFieldTags(f reflect.StructField) fieldTags {
 func (g *Generator) genTypeEncoder(t reflect.Type, in string, tags fieldTags, indent int, assumeNonEmpty bool) error {
 	ws := strings.Repeat("  ", indent)
 
+	if ok, err := g.genCodecEncoder(t, in, indent); ok || err != nil {
+		return err
+	}
+	if g.genExternalEncoder(t, in, indent) {
+		return nil
+	}
+
+	if g.proto3JSON {
+		if ok := g.genProto3Encoder(t, in, tags, indent); ok {
+			return nil
+		}
+	}
+
 	marshalerIface := reflect.TypeOf((*tinyjson.Marshaler)(nil)).Elem()
 	if reflect.PtrTo(t).Implements(marshalerIface) {
 		fmt.Fprintln(g.out, ws+"("+in+").MarshalTinyJSON(out)")
@@ -127,10 +156,32 @@ func hasCustomMarshaler(t reflect.Type) bool {
 		t.Implements(reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem())
 }
 
+// is64BitKind returns true for 64-bit integer kinds, which both Amino JSON and proto3 JSON
+// encode as strings.
+func is64BitKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
 // genTypeEncoderNoCheck generates code that encodes in of type t into the writer.
 func (g *Generator) genTypeEncoderNoCheck(t reflect.Type, in string, tags fieldTags, indent int, assumeNonEmpty bool) error {
 	ws := strings.Repeat("  ", indent)
 
+	if typeParamIndex(t) >= 0 {
+		if err := g.checkTypeParamTags(t, tags); err != nil {
+			return err
+		}
+		g.genTypeParamEncoder(in, indent)
+		return nil
+	}
+
+	if (g.aminoJSON || g.proto3JSON) && is64BitKind(t.Kind()) {
+		tags.asString = true
+	}
+
 	// Check whether type is primitive, needs to be done after interface check.
 	if enc := primitiveStringEncoders[t.Kind()]; enc != "" && tags.asString {
 		fmt.Fprintf(g.out, ws+enc+"\n", in)
@@ -149,7 +200,9 @@ func (g *Generator) genTypeEncoderNoCheck(t reflect.Type, in string, tags fieldT
 		vVar := g.uniqueVarName()
 
 		if t.Elem().Kind() == reflect.Uint8 && elem.Name() == "uint8" {
-			if g.simpleBytes {
+			if tags.hex {
+				fmt.Fprintln(g.out, ws+"out.HexBytes("+in+")")
+			} else if g.simpleBytes && !tags.base64 {
 				fmt.Fprintln(g.out, ws+"out.String(string("+in+"))")
 			} else {
 				fmt.Fprintln(g.out, ws+"out.Base64Bytes("+in+")")
@@ -182,7 +235,9 @@ func (g *Generator) genTypeEncoderNoCheck(t reflect.Type, in string, tags fieldT
 		iVar := g.uniqueVarName()
 
 		if t.Elem().Kind() == reflect.Uint8 && elem.Name() == "uint8" {
-			if g.simpleBytes {
+			if tags.hex {
+				fmt.Fprintln(g.out, ws+"out.HexBytes("+in+"[:])")
+			} else if g.simpleBytes && !tags.base64 {
 				fmt.Fprintln(g.out, ws+"out.String(string("+in+"[:]))")
 			} else {
 				fmt.Fprintln(g.out, ws+"out.Base64Bytes("+in+"[:])")
@@ -224,9 +279,13 @@ func (g *Generator) genTypeEncoderNoCheck(t reflect.Type, in string, tags fieldT
 		}
 
 	case reflect.Map:
+		if g.aminoJSON {
+			return g.genAminoMapEncoder(t, in, tags, indent, assumeNonEmpty)
+		}
+
 		key := t.Key()
 		keyEnc, ok := primitiveStringEncoders[key.Kind()]
-		if !ok && !hasCustomMarshaler(key) {
+		if !ok && !hasCustomMarshaler(key) && !g.hasCodec(key) {
 			return fmt.Errorf("map key type %v not supported: only string and integer keys and types implementing Marshaler interfaces are allowed", key)
 		} // else assume the caller knows what they are doing and that the custom marshaler performs the translation from the key type to a string or integer
 		tmpVar := g.uniqueVarName()
@@ -244,7 +303,11 @@ func (g *Generator) genTypeEncoderNoCheck(t reflect.Type, in string, tags fieldT
 		fmt.Fprintln(g.out, ws+"    if "+tmpVar+"First { "+tmpVar+"First = false } else { out.RawByte(',') }")
 
 		// NOTE: extra check for TextMarshaler. It overrides default methods.
-		if reflect.PtrTo(key).Implements(reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()) {
+		if g.hasCodec(key) {
+			if err := g.genTypeEncoder(key, tmpVar+"Name", tags, indent+2, false); err != nil {
+				return err
+			}
+		} else if reflect.PtrTo(key).Implements(reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()) {
 			fmt.Fprintln(g.out, ws+"    "+fmt.Sprintf("out.RawText(("+tmpVar+"Name).MarshalText()"+")"))
 		} else if keyEnc != "" {
 			fmt.Fprintln(g.out, ws+"    "+fmt.Sprintf(keyEnc, tmpVar+"Name"))
@@ -265,6 +328,10 @@ func (g *Generator) genTypeEncoderNoCheck(t reflect.Type, in string, tags fieldT
 		fmt.Fprintln(g.out, ws+"}")
 
 	case reflect.Interface:
+		if g.aminoJSON {
+			return g.genAminoInterfaceEncoder(t, in, indent)
+		}
+
 		if t.NumMethod() != 0 {
 			if g.interfaceIsEasyjsonMarshaller(t) {
 				fmt.Fprintln(g.out, ws+in+".MarshalTinyJSON(out)")
@@ -292,6 +359,73 @@ func (g *Generator) genTypeEncoderNoCheck(t reflect.Type, in string, tags fieldT
 	return nil
 }
 
+// genAminoMapEncoder generates code that encodes a string-keyed map with its keys sorted.
+func (g *Generator) genAminoMapEncoder(t reflect.Type, in string, tags fieldTags, indent int, assumeNonEmpty bool) error {
+	ws := strings.Repeat("  ", indent)
+
+	key := t.Key()
+	if key.Kind() != reflect.String {
+		return fmt.Errorf("map key type %v not supported: only string keys are allowed in Amino JSON mode", key)
+	}
+	g.imports["sort"] = "sort"
+	tmpVar := g.uniqueVarName()
+
+	if !assumeNonEmpty {
+		fmt.Fprintln(g.out, ws+"if "+in+" == nil && (out.Flags & jwriter.NilMapAsEmpty) == 0 {")
+		fmt.Fprintln(g.out, ws+"  out.RawString(`null`)")
+		fmt.Fprintln(g.out, ws+"} else {")
+	} else {
+		fmt.Fprintln(g.out, ws+"{")
+	}
+	fmt.Fprintln(g.out, ws+"  "+tmpVar+"Keys := make([]string, 0, len("+in+"))")
+	fmt.Fprintln(g.out, ws+"  for "+tmpVar+"Name := range "+in+" {")
+	fmt.Fprintln(g.out, ws+"    "+tmpVar+"Keys = append("+tmpVar+"Keys, string("+tmpVar+"Name))")
+	fmt.Fprintln(g.out, ws+"  }")
+	fmt.Fprintln(g.out, ws+"  sort.Strings("+tmpVar+"Keys)")
+	fmt.Fprintln(g.out, ws+"  out.RawByte('{')")
+	fmt.Fprintln(g.out, ws+"  for "+tmpVar+"I, "+tmpVar+"Name := range "+tmpVar+"Keys {")
+	fmt.Fprintln(g.out, ws+"    if "+tmpVar+"I > 0 { out.RawByte(',') }")
+	fmt.Fprintln(g.out, ws+"    out.String("+tmpVar+"Name)")
+	fmt.Fprintln(g.out, ws+"    out.RawByte(':')")
+	fmt.Fprintln(g.out, ws+"    "+tmpVar+"Value := ("+in+")["+g.getType(key)+"("+tmpVar+"Name)]")
+
+	if err := g.genTypeEncoder(t.Elem(), tmpVar+"Value", tags, indent+2, false); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(g.out, ws+"  }")
+	fmt.Fprintln(g.out, ws+"  out.RawByte('}')")
+	fmt.Fprintln(g.out, ws+"}")
+	return nil
+}
+
+// genAminoInterfaceEncoder generates code that encodes an interface value, wrapping values of
+// registered types into {"type":...,"value":...} objects.
+func (g *Generator) genAminoInterfaceEncoder(t reflect.Type, in string, indent int) error {
+	ws := strings.Repeat("  ", indent)
+
+	if t.NumMethod() != 0 && !g.interfaceIsEasyjsonMarshaller(t) {
+		return fmt.Errorf("interface type %v not supported: only interface{} and interfaces that implement tinyjson Marshaling are allowed in Amino JSON mode", t)
+	}
+
+	fmt.Fprintln(g.out, ws+"if "+in+" == nil {")
+	fmt.Fprintln(g.out, ws+`  out.RawString("null")`)
+	fmt.Fprintln(g.out, ws+"} else if m, ok := "+in+".(tinyjson.Marshaler); ok {")
+	fmt.Fprintln(g.out, ws+"  if n, ok := m.(tinyjson.AminoNamer); ok {")
+	fmt.Fprintln(g.out, ws+"    out.RawString(`{\"type\":`)")
+	fmt.Fprintln(g.out, ws+"    out.String(n.AminoName())")
+	fmt.Fprintln(g.out, ws+"    out.RawString(`,\"value\":`)")
+	fmt.Fprintln(g.out, ws+"    m.MarshalTinyJSON(out)")
+	fmt.Fprintln(g.out, ws+"    out.RawByte('}')")
+	fmt.Fprintln(g.out, ws+"  } else {")
+	fmt.Fprintln(g.out, ws+"    m.MarshalTinyJSON(out)")
+	fmt.Fprintln(g.out, ws+"  }")
+	fmt.Fprintln(g.out, ws+"} else {")
+	fmt.Fprintln(g.out, ws+"  out.Error = tinyjson.ErrAminoNotMarshaler")
+	fmt.Fprintln(g.out, ws+"}")
+	return nil
+}
+
 func (g *Generator) interfaceIsEasyjsonMarshaller(t reflect.Type) bool {
 	return t.Implements(reflect.TypeOf((*tinyjson.Marshaler)(nil)).Elem())
 }
@@ -311,6 +445,12 @@ func (g *Generator) notEmptyCheck(t reflect.Type, v string) string {
 		return "len(" + v + ") != 0"
 	case reflect.Interface, reflect.Ptr:
 		return v + " != nil"
+	case reflect.Struct:
+		// Amino JSON treats a struct with all fields empty as an empty value.
+		if g.aminoJSON && t.Comparable() {
+			return v + " != (" + g.getType(t) + "{})"
+		}
+		return "true"
 	case reflect.Bool:
 		return v
 	case reflect.String:
@@ -328,7 +468,7 @@ func (g *Generator) notEmptyCheck(t reflect.Type, v string) string {
 }
 
 func (g *Generator) genStructFieldEncoder(t reflect.Type, f reflect.StructField, first, firstCondition bool) (bool, error) {
-	jsonName := g.fieldNamer.GetJSONFieldName(t, f)
+	jsonName := g.jsonFieldName(t, f)
 	tags := parseFieldTags(f)
 
 	if tags.omit {
@@ -338,6 +478,14 @@ func (g *Generator) genStructFieldEncoder(t reflect.Type, f reflect.StructField,
 	toggleFirstCondition := firstCondition
 
 	noOmitEmpty := (!tags.omitEmpty && !g.omitEmpty) || tags.noOmitEmpty
+	if !noOmitEmpty && typeParamIndex(f.Type) >= 0 {
+		// Whether a value of a type parameter is empty is only known at run time: values are
+		// always written, and an explicit omitempty is rejected rather than ignored.
+		if tags.omitEmpty {
+			return firstCondition, fmt.Errorf("tag option %q not supported on values of type parameter %s", "omitempty", g.typeParamName(typeParamIndex(f.Type)))
+		}
+		noOmitEmpty = true
+	}
 	if noOmitEmpty {
 		fmt.Fprintln(g.out, "  {")
 		toggleFirstCondition = false
@@ -347,7 +495,7 @@ func (g *Generator) genStructFieldEncoder(t reflect.Type, f reflect.StructField,
 	}
 
 	if firstCondition {
-		fmt.Fprintf(g.out, "    const prefix string = %q\n", ","+strconv.Quote(jsonName)+":")
+		g.genFieldPrefix(jsonName)
 		if first {
 			if !noOmitEmpty {
 				fmt.Fprintln(g.out, "      first = false")
@@ -362,7 +510,7 @@ func (g *Generator) genStructFieldEncoder(t reflect.Type, f reflect.StructField,
 			fmt.Fprintln(g.out, "    }")
 		}
 	} else {
-		fmt.Fprintf(g.out, "    const prefix string = %q\n", ","+strconv.Quote(jsonName)+":")
+		g.genFieldPrefix(jsonName)
 		fmt.Fprintln(g.out, "    out.RawString(prefix)")
 	}
 
@@ -373,6 +521,57 @@ func (g *Generator) genStructFieldEncoder(t reflect.Type, f reflect.StructField,
 	return toggleFirstCondition, nil
 }
 
+// genFieldPrefix generates the declaration of prefix, the member name of a field preceded by a
+// comma and followed by a colon. Non-ASCII characters of the name are escaped by writers with
+// ASCIIOnly set.
+func (g *Generator) genFieldPrefix(jsonName string) {
+	prefix := "," + strconv.Quote(jsonName) + ":"
+	if isASCII(jsonName) {
+		fmt.Fprintf(g.out, "    const prefix string = %q\n", prefix)
+		return
+	}
+	fmt.Fprintf(g.out, "    prefix := %q\n", prefix)
+	fmt.Fprintln(g.out, "    if out.ASCIIOnly {")
+	fmt.Fprintf(g.out, "      prefix = %q\n", ","+asciiJSONString(jsonName)+":")
+	fmt.Fprintln(g.out, "    }")
+}
+
+// isASCII reports whether s only holds ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// asciiJSONString returns s as a JSON string in ASCII, escaping other characters as \uXXXX, and
+// as UTF-16 surrogate pairs above U+FFFF, like jwriter.Writer.String with ASCIIOnly set.
+func asciiJSONString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		default:
+			if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+				fmt.Fprintf(&b, `\u%04x\u%04x`, r1, r2)
+			} else {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
 func (g *Generator) genEncoder(t reflect.Type) error {
 	switch t.Kind() {
 	case reflect.Slice, reflect.Array, reflect.Map:
@@ -392,7 +591,7 @@ func (g *Generator) genSliceArrayMapEncoder(t reflect.Type) error {
 	fname := g.getEncoderName(t)
 	typ := g.getType(t)
 
-	fmt.Fprintln(g.out, "func "+fname+"(out *jwriter.Writer, in "+typ+") {")
+	fmt.Fprintln(g.out, "func "+fname+g.typeParamList(t)+"(out *jwriter.Writer, in "+typ+") {")
 	err := g.genTypeEncoderNoCheck(t, "in", fieldTags{}, 1, false)
 	if err != nil {
 		return err
@@ -409,7 +608,7 @@ func (g *Generator) genStructEncoder(t reflect.Type) error {
 	fname := g.getEncoderName(t)
 	typ := g.getType(t)
 
-	fmt.Fprintln(g.out, "func "+fname+"(out *jwriter.Writer, in "+typ+") {")
+	fmt.Fprintln(g.out, "func "+fname+g.typeParamList(t)+"(out *jwriter.Writer, in "+typ+") {")
 	fmt.Fprintln(g.out, "  out.RawByte('{')")
 	fmt.Fprintln(g.out, "  first := true")
 	fmt.Fprintln(g.out, "  _ = first")
@@ -418,6 +617,12 @@ func (g *Generator) genStructEncoder(t reflect.Type) error {
 	if err != nil {
 		return fmt.Errorf("cannot generate encoder for %v: %v", t, err)
 	}
+	if g.aminoJSON {
+		// Amino JSON requires keys to be sorted, as done by sdk.MustSortJSON.
+		sort.SliceStable(fs, func(i, j int) bool {
+			return g.jsonFieldName(t, fs[i]) < g.jsonFieldName(t, fs[j])
+		})
+	}
 
 	firstCondition := true
 	for i, f := range fs {
@@ -466,5 +671,23 @@ func (g *Generator) genStructMarshaler(t reflect.Type) error {
 	fmt.Fprintln(g.out, "  "+fname+"(w, v)")
 	fmt.Fprintln(g.out, "}")
 
+	fmt.Fprintln(g.out, "// AppendTinyJSON supports tinyjson.Appender interface")
+	fmt.Fprintln(g.out, "func (v "+typ+") AppendTinyJSON(dst []byte) []byte {")
+	fmt.Fprintln(g.out, "  w := jwriter.Writer{}")
+	fmt.Fprintln(g.out, "  w.AppendTo(dst)")
+	fmt.Fprintln(g.out, "  "+fname+"(&w, v)")
+	fmt.Fprintln(g.out, "  if w.Error != nil {")
+	fmt.Fprintln(g.out, "    return dst")
+	fmt.Fprintln(g.out, "  }")
+	fmt.Fprintln(g.out, "  return w.Buffer.Buf")
+	fmt.Fprintln(g.out, "}")
+
+	if name, ok := g.aminoNames[t]; ok {
+		fmt.Fprintln(g.out, "// AminoName supports tinyjson.AminoNamer interface")
+		fmt.Fprintln(g.out, "func (v "+typ+") AminoName() string {")
+		fmt.Fprintf(g.out, "  return %q\n", name)
+		fmt.Fprintln(g.out, "}")
+	}
+
 	return nil
 }
@@ -0,0 +1,78 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AddExternal requests standalone functions encoding and decoding the named type of given object,
+// declared in another package, for which no methods can be generated:
+//
+//	func EncodeName(w *jwriter.Writer, v *pkg.T)
+//	func DecodeName(l *jlexer.Lexer, v *pkg.T)
+//
+// Values of the type are encoded and decoded with the functions wherever they appear in the
+// generated package, e.g. as fields of its structs.
+func (g *Generator) AddExternal(obj interface{}, name string) {
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	g.externals[t] = name
+	g.addType(t)
+}
+
+// addressOf returns the expression of a pointer to the value of expr.
+func addressOf(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return expr[1:]
+	}
+	return "&" + expr
+}
+
+// genExternalEncoder generates code encoding in with the function generated for the type t, if
+// it is an external type.
+func (g *Generator) genExternalEncoder(t reflect.Type, in string, indent int) bool {
+	name, ok := g.externals[t]
+	if !ok {
+		return false
+	}
+	fmt.Fprintln(g.out, strings.Repeat("  ", indent)+"Encode"+name+"(out, "+addressOf(in)+")")
+	return true
+}
+
+// genExternalDecoder generates code decoding out with the function generated for the type t, if
+// it is an external type.
+func (g *Generator) genExternalDecoder(t reflect.Type, out string, indent int) bool {
+	name, ok := g.externals[t]
+	if !ok {
+		return false
+	}
+	fmt.Fprintln(g.out, strings.Repeat("  ", indent)+"Decode"+name+"(in, "+addressOf(out)+")")
+	return true
+}
+
+// genExternalFuncs generates the standalone functions of the external type t.
+func (g *Generator) genExternalFuncs(t reflect.Type) error {
+	if t.PkgPath() == g.pkgPath {
+		return fmt.Errorf("cannot generate functions for %v, declared in the generated package", t)
+	}
+	if hasTypeParam(t) {
+		return fmt.Errorf("cannot generate functions for %v, a generic type", t)
+	}
+	name := g.externals[t]
+	typ := g.getType(t)
+
+	fmt.Fprintln(g.out, "// Encode"+name+" writes the JSON encoding of v, declared in another package.")
+	fmt.Fprintln(g.out, "func Encode"+name+"(w *jwriter.Writer, v *"+typ+") {")
+	fmt.Fprintln(g.out, "  "+g.getEncoderName(t)+"(w, *v)")
+	fmt.Fprintln(g.out, "}")
+	fmt.Fprintln(g.out)
+	fmt.Fprintln(g.out, "// Decode"+name+" decodes the JSON from l into v, declared in another package.")
+	fmt.Fprintln(g.out, "func Decode"+name+"(l *jlexer.Lexer, v *"+typ+") {")
+	fmt.Fprintln(g.out, "  "+g.getDecoderName(t)+"(l, v)")
+	fmt.Fprintln(g.out, "}")
+	fmt.Fprintln(g.out)
+	return nil
+}
@@ -33,12 +33,37 @@ type Generator struct {
 
 	varCounter int
 
-	noStdMarshalers          bool
-	omitEmpty                bool
-	disallowUnknownFields    bool
-	fieldNamer               FieldNamer
-	simpleBytes              bool
-	skipMemberNameUnescaping bool
+	noStdMarshalers bool
+	resetOnDecode   bool
+	interfaceValue  bool
+	simpleBytes     bool
+	aminoJSON       bool
+	proto3JSON      bool
+	metadata        bool
+
+	// options of the type being generated, the ones of the generator unless overridden
+	typeOptions
+
+	// options set for types with SetTypeOptions
+	optionsByType map[reflect.Type][]string
+
+	// member names of fields set with RenameFields, by field or type and field name
+	renames map[string]string
+
+	// codecs registered for types, by fully qualified type name
+	codecs map[string]codec
+
+	// types of other packages standalone functions are generated for, to their names
+	externals map[reflect.Type]string
+
+	// Amino JSON type names of registered types
+	aminoNames map[reflect.Type]string
+
+	// type parameters of generic types, instantiated with placeholder types
+	typeParams map[reflect.Type][]TypeParam
+
+	// type parameters of the generic type funcs are being generated for
+	curTypeParams []TypeParam
 
 	// package path to local alias map for tracking imports
 	imports map[string]string
@@ -65,8 +90,13 @@ func NewGenerator(filename string) *Generator {
 			pkgLexer:    "jlexer",
 			pkgTinyJSON: "tinyjson",
 		},
-		fieldNamer:    DefaultFieldNamer{},
+		typeOptions:   typeOptions{fieldNamer: DefaultFieldNamer{}},
+		optionsByType: make(map[reflect.Type][]string),
+		codecs:        make(map[string]codec),
+		externals:     make(map[reflect.Type]string),
 		marshalers:    make(map[reflect.Type]bool),
+		aminoNames:    make(map[reflect.Type]string),
+		typeParams:    make(map[reflect.Type][]TypeParam),
 		typesSeen:     make(map[reflect.Type]bool),
 		functionNames: make(map[string]reflect.Type),
 	}
@@ -106,6 +136,28 @@ func (g *Generator) UseLowerCamelCase() {
 	g.fieldNamer = LowerCamelCaseFieldNamer{}
 }
 
+// UseKebabCase sets kebab-case field naming strategy.
+func (g *Generator) UseKebabCase() {
+	g.fieldNamer = KebabCaseFieldNamer{}
+}
+
+// UseScreamingSnakeCase sets SCREAMING_SNAKE_CASE field naming strategy.
+func (g *Generator) UseScreamingSnakeCase() {
+	g.fieldNamer = ScreamingSnakeCaseFieldNamer{}
+}
+
+// UsePascalCase sets PascalCase field naming strategy.
+func (g *Generator) UsePascalCase() {
+	g.fieldNamer = PascalCaseFieldNamer{}
+}
+
+// RenameFields sets the member names of fields, given by field name, e.g. "Denom", or by type
+// and field name, e.g. "Coin.Denom", the latter taking precedence. The names take precedence
+// over the field naming strategy, but not over json tags.
+func (g *Generator) RenameFields(names map[string]string) {
+	g.renames = names
+}
+
 // NoStdMarshalers instructs not to generate standard MarshalJSON/UnmarshalJSON
 // methods (only the custom interface).
 func (g *Generator) NoStdMarshalers() {
@@ -117,6 +169,19 @@ func (g *Generator) DisallowUnknownFields() {
 	g.disallowUnknownFields = true
 }
 
+// ResetOnDecode makes struct decoders always reset the target to its zero value before decoding,
+// rather than only when the lexer's ResetOnDecode flag is set.
+func (g *Generator) ResetOnDecode() {
+	g.resetOnDecode = true
+}
+
+// InterfaceValue makes decoders decode interface{} values into tinyjson.Value trees, which keep
+// the order of object members and are encoded without reflection, rather than into
+// map[string]interface{} and []interface{}.
+func (g *Generator) InterfaceValue() {
+	g.interfaceValue = true
+}
+
 // SkipMemberNameUnescaping instructs to skip member names unescaping to improve performance
 func (g *Generator) SkipMemberNameUnescaping() {
 	g.skipMemberNameUnescaping = true
@@ -132,6 +197,33 @@ func (g *Generator) SimpleBytes() {
 	g.simpleBytes = true
 }
 
+// AminoJSON switches encoders to the legacy Amino JSON format used for Cosmos SDK sign docs:
+// object keys are sorted, 64-bit integers are written as strings and values of registered
+// types are wrapped into {"type":...,"value":...} objects when encoded through an interface.
+func (g *Generator) AminoJSON() {
+	g.aminoJSON = true
+}
+
+// RegisterAminoName registers the Amino JSON type name for the type of given object. An
+// AminoName method returning the name is generated for the type.
+func (g *Generator) RegisterAminoName(obj interface{}, name string) {
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	g.aminoNames[t] = name
+}
+
+// Proto3JSON makes encoders and decoders follow the proto3 JSON mapping: lowerCamelCase names
+// (the original names are accepted as well), 64-bit integers as strings (numbers are accepted as
+// well), enums as names, default values omitted and the Timestamp, Duration and Any well-known
+// types in their special representations.
+func (g *Generator) Proto3JSON() {
+	g.proto3JSON = true
+	g.omitEmpty = true
+	g.fieldNamer = LowerCamelCaseFieldNamer{}
+}
+
 // addTypes requests to generate encoding/decoding funcs for the given type.
 func (g *Generator) addType(t reflect.Type) {
 	if g.typesSeen[t] {
@@ -197,17 +289,34 @@ func (g *Generator) printHeader() {
 func (g *Generator) Run(out io.Writer) error {
 	g.out = &bytes.Buffer{}
 
+	defaults := g.typeOptions
+	defer func() { g.typeOptions = defaults }()
+
 	for len(g.typesUnseen) > 0 {
 		t := g.typesUnseen[len(g.typesUnseen)-1]
 		g.typesUnseen = g.typesUnseen[:len(g.typesUnseen)-1]
 		g.typesSeen[t] = true
 
+		if err := g.checkGeneric(t); err != nil {
+			return err
+		}
+		g.curTypeParams = g.typeParams[t]
+		g.typeOptions = defaults
+		if err := g.typeOptions.set(t, g.optionsByType[t]); err != nil {
+			return err
+		}
+
 		if err := g.genDecoder(t); err != nil {
 			return err
 		}
 		if err := g.genEncoder(t); err != nil {
 			return err
 		}
+		if _, ok := g.externals[t]; ok {
+			if err := g.genExternalFuncs(t); err != nil {
+				return err
+			}
+		}
 
 		if !g.marshalers[t] {
 			continue
@@ -219,6 +328,11 @@ func (g *Generator) Run(out io.Writer) error {
 		if err := g.genStructUnmarshaler(t); err != nil {
 			return err
 		}
+		if g.metadata {
+			if err := g.genTypeInfo(t); err != nil {
+				return err
+			}
+		}
 	}
 	g.printHeader()
 	_, err := out.Write(g.out.Bytes())
@@ -278,6 +392,10 @@ func (g *Generator) pkgAlias(pkgPath string) string {
 
 // getType return the textual type name of given type that can be used in generated code.
 func (g *Generator) getType(t reflect.Type) string {
+	if i := typeParamIndex(t); i >= 0 {
+		return g.typeParamName(i)
+	}
+
 	if t.Name() == "" {
 		switch t.Kind() {
 		case reflect.Ptr:
@@ -316,9 +434,9 @@ func (g *Generator) getType(t reflect.Type) string {
 		}
 		return t.String()
 	} else if t.PkgPath() == g.pkgPath {
-		return t.Name()
+		return g.typeName(t)
 	}
-	return g.pkgAlias(t.PkgPath()) + "." + t.Name()
+	return g.pkgAlias(t.PkgPath()) + "." + g.typeName(t)
 }
 
 // escape a struct field tag string back to source code
@@ -344,7 +462,7 @@ func (g *Generator) safeName(t reflect.Type) string {
 	if t.Name() == "" {
 		name += "anonymous"
 	} else {
-		name += "." + t.Name()
+		name += "." + safeTypeName(t)
 	}
 
 	parts := []string{}
@@ -392,6 +510,73 @@ func (g *Generator) functionName(prefix string, t reflect.Type) string {
 	}
 }
 
+// protobufTagOption returns the value of the key=value option in the protobuf struct tag, as
+// generated by protoc-gen-go and protoc-gen-gogo.
+func protobufTagOption(f reflect.StructField, key string) string {
+	for _, s := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(s, key+"=") {
+			return s[len(key)+1:]
+		}
+	}
+	return ""
+}
+
+// jsonFieldName returns the member name the field is encoded with.
+func (g *Generator) jsonFieldName(t reflect.Type, f reflect.StructField) string {
+	if g.proto3JSON {
+		if name := protobufTagOption(f, "json"); name != "" {
+			return name
+		}
+		if name := protobufTagOption(f, "name"); name != "" {
+			return name
+		}
+	}
+	if name := g.renamedField(t, f); name != "" {
+		return name
+	}
+	return g.fieldNamer.GetJSONFieldName(t, f)
+}
+
+// renamedField returns the member name set for the field with RenameFields, if any and the
+// field has no json tag name.
+func (g *Generator) renamedField(t reflect.Type, f reflect.StructField) string {
+	if len(g.renames) == 0 || parseFieldTags(f).name != "" {
+		return ""
+	}
+	typeName := t.Name()
+	if i := strings.IndexByte(typeName, '['); i != -1 {
+		typeName = typeName[:i]
+	}
+	if name := g.renames[typeName+"."+f.Name]; name != "" {
+		return name
+	}
+	return g.renames[f.Name]
+}
+
+// jsonFieldNames returns all member names accepted when decoding the field, the name the field
+// is encoded with being the first one.
+func (g *Generator) jsonFieldNames(t reflect.Type, f reflect.StructField) []string {
+	names := []string{g.jsonFieldName(t, f)}
+	if !g.proto3JSON {
+		return names
+	}
+
+	orig := protobufTagOption(f, "name")
+	if orig == "" {
+		orig = camelToSnake(f.Name)
+	}
+	for _, name := range []string{orig, parseFieldTags(f).name} {
+		found := name == ""
+		for _, n := range names {
+			found = found || n == name
+		}
+		if !found {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // DefaultFieldsNamer implements trivial naming policy equivalent to encoding/json.
 type DefaultFieldNamer struct{}
 
@@ -527,6 +712,55 @@ func (SnakeCaseFieldNamer) GetJSONFieldName(t reflect.Type, f reflect.StructFiel
 	return camelToSnake(f.Name)
 }
 
+// KebabCaseFieldNamer implements CamelCase to kebab-case conversion for fields names.
+type KebabCaseFieldNamer struct{}
+
+func (KebabCaseFieldNamer) GetJSONFieldName(t reflect.Type, f reflect.StructField) string {
+	jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+	if jsonName != "" {
+		return jsonName
+	}
+
+	return strings.Replace(camelToSnake(f.Name), "_", "-", -1)
+}
+
+// ScreamingSnakeCaseFieldNamer implements CamelCase to SCREAMING_SNAKE_CASE conversion for
+// fields names.
+type ScreamingSnakeCaseFieldNamer struct{}
+
+func (ScreamingSnakeCaseFieldNamer) GetJSONFieldName(t reflect.Type, f reflect.StructField) string {
+	jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+	if jsonName != "" {
+		return jsonName
+	}
+
+	return strings.ToUpper(camelToSnake(f.Name))
+}
+
+// PascalCaseFieldNamer implements conversion to PascalCase for fields names, where only the first
+// letter of words is uppercase (HTTPServer is converted to "HttpServer").
+type PascalCaseFieldNamer struct{}
+
+// camelToPascal converts HTTPRestClient to HttpRestClient.
+func camelToPascal(name string) string {
+	words := strings.Split(camelToSnake(name), "_")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+func (PascalCaseFieldNamer) GetJSONFieldName(t reflect.Type, f reflect.StructField) string {
+	jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+	if jsonName != "" {
+		return jsonName
+	}
+
+	return camelToPascal(f.Name)
+}
+
 func joinFunctionNameParts(keepFirst bool, parts ...string) string {
 	buf := bytes.NewBufferString("")
 	for i, part := range parts {
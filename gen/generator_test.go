@@ -1,7 +1,12 @@
 package gen
 
 import (
+	"io"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/CosmWasm/tinyjson/gen/typeparam"
 )
 
 func TestCamelToSnake(t *testing.T) {
@@ -50,6 +55,86 @@ func TestCamelToLowerCamel(t *testing.T) {
 	}
 }
 
+func TestFieldNamers(t *testing.T) {
+	for i, test := range []struct {
+		Namer FieldNamer
+		In    string
+		Out   string
+	}{
+		{KebabCaseFieldNamer{}, "SomeHTTPStuff", "some-http-stuff"},
+		{KebabCaseFieldNamer{}, "HTTP2Server", "http2-server"},
+		{ScreamingSnakeCaseFieldNamer{}, "SomeHTTPStuff", "SOME_HTTP_STUFF"},
+		{ScreamingSnakeCaseFieldNamer{}, "ID", "ID"},
+		{PascalCaseFieldNamer{}, "SomeHTTPStuff", "SomeHttpStuff"},
+		{PascalCaseFieldNamer{}, "Some_Mixed_Case", "SomeMixedCase"},
+		{PascalCaseFieldNamer{}, "HTTP2Server", "Http2Server"},
+	} {
+		f := reflect.StructField{Name: test.In}
+		if got := test.Namer.GetJSONFieldName(nil, f); got != test.Out {
+			t.Errorf("[%d] %T.GetJSONFieldName(%s) = %s; want %s", i, test.Namer, test.In, got, test.Out)
+		}
+
+		f.Tag = `json:"tagged"`
+		if got := test.Namer.GetJSONFieldName(nil, f); got != "tagged" {
+			t.Errorf("[%d] %T.GetJSONFieldName(%s) with a json tag = %s; want tagged", i, test.Namer, test.In, got)
+		}
+	}
+}
+
+func TestCodecFunc(t *testing.T) {
+	g := NewGenerator("codecs_tinyjson.go")
+	g.SetPkg("models", "example.com/models")
+	for i, test := range []struct {
+		In, Out string
+	}{
+		{"encodeInt", "encodeInt"},
+		{"example.com/models.encodeInt", "encodeInt"},
+		{"math/big.EncodeInt", "big.EncodeInt"},
+		{"example.com/big-json.Encode", "big_json.Encode"},
+	} {
+		if got := g.codecFunc(test.In); got != test.Out {
+			t.Errorf("[%d] codecFunc(%s) = %s; want %s", i, test.In, got, test.Out)
+		}
+	}
+	if g.imports["math/big"] != "big" || g.imports["example.com/big-json"] != "big_json" {
+		t.Errorf("imports = %v", g.imports)
+	}
+}
+
+func TestStdIncompatibility(t *testing.T) {
+	type plain struct {
+		ID   uint64 `json:"id,string"`
+		Tags []string
+		Raw  []byte
+	}
+	type hexBytes struct {
+		Hash []byte `json:"hash,hex"`
+	}
+	type nested struct {
+		Items []*struct{ Digest [32]byte }
+	}
+
+	for i, test := range []struct {
+		Type    reflect.Type
+		Options []string
+		Out     string
+	}{
+		{reflect.TypeOf(plain{}), nil, ""},
+		{reflect.TypeOf(plain{}), []string{"snake_case"}, "member naming"},
+		{reflect.TypeOf(plain{}), []string{"omit_empty"}, "omit_empty"},
+		{reflect.TypeOf(hexBytes{}), nil, "the hex tag of hexBytes.Hash"},
+		{reflect.TypeOf(nested{}), nil, "the byte array [32]uint8"},
+	} {
+		g := NewGenerator("tests_tinyjson.go")
+		if err := g.typeOptions.set(test.Type, test.Options); err != nil {
+			t.Fatal(err)
+		}
+		if got := g.stdIncompatibility(test.Type); got != test.Out {
+			t.Errorf("[%d] stdIncompatibility(%v) = %q; want %q", i, test.Type, got, test.Out)
+		}
+	}
+}
+
 func TestJoinFunctionNameParts(t *testing.T) {
 	for i, test := range []struct {
 		keepFirst bool
@@ -85,3 +170,106 @@ func TestFixVendorPath(t *testing.T) {
 	}
 
 }
+
+type genericPair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func TestGenericTypeNames(t *testing.T) {
+	g := NewGenerator("test.go")
+	g.SetPkg("gen", "github.com/CosmWasm/tinyjson/gen")
+	g.SetTypeParams((*genericPair[typeparam.P0, typeparam.P1])(nil), "K comparable", "V")
+
+	typ := reflect.TypeOf(genericPair[typeparam.P0, typeparam.P1]{})
+	g.curTypeParams = g.typeParams[typ]
+	for i, test := range []struct {
+		Type                reflect.Type
+		Name, SafeName, TPs string
+	}{
+		{typ, "genericPair[K,V]", "GithubComCosmWasmTinyjsonGenGenericPair", "[K comparable, V any]"},
+		{reflect.TypeOf(genericPair[string, []typeparam.P1]{}), "genericPair[string,[]V]", "GithubComCosmWasmTinyjsonGenGenericPairString", ""},
+		{reflect.TypeOf(genericPair[int, reflect.Value]{}), "genericPair[int,reflect.Value]", "GithubComCosmWasmTinyjsonGenGenericPairIntReflectValue", ""},
+	} {
+		if got := g.getType(test.Type); got != test.Name {
+			t.Errorf("[%d] getType() = %s; want %s", i, got, test.Name)
+		}
+		if got := g.safeName(test.Type); got != test.SafeName {
+			t.Errorf("[%d] safeName() = %s; want %s", i, got, test.SafeName)
+		}
+		if got := g.typeParamList(test.Type); got != test.TPs {
+			t.Errorf("[%d] typeParamList() = %s; want %s", i, got, test.TPs)
+		}
+	}
+}
+
+type genericTagged[T any] struct {
+	Value T `json:"value,string"`
+}
+
+func TestGenericTypeParamTags(t *testing.T) {
+	g := NewGenerator("test.go")
+	g.SetPkg("gen", "github.com/CosmWasm/tinyjson/gen")
+	g.SetTypeParams((*genericTagged[typeparam.P0])(nil), "T any")
+	g.Add((*genericTagged[typeparam.P0])(nil))
+
+	err := g.Run(io.Discard)
+	if err == nil || !strings.Contains(err.Error(), `"string"`) {
+		t.Errorf("Run() error = %v; want the string option of a T value rejected", err)
+	}
+}
+
+type genericOmitted[T any] struct {
+	Value T `json:"value,omitempty"`
+}
+
+func TestGenericTypeParamOmitEmpty(t *testing.T) {
+	for _, aminoJSON := range []bool{false, true} {
+		g := NewGenerator("test.go")
+		g.SetPkg("gen", "github.com/CosmWasm/tinyjson/gen")
+		if aminoJSON {
+			g.AminoJSON()
+		}
+		g.SetTypeParams((*genericOmitted[typeparam.P0])(nil), "T any")
+		g.Add((*genericOmitted[typeparam.P0])(nil))
+
+		err := g.Run(io.Discard)
+		if err == nil || !strings.Contains(err.Error(), `"omitempty"`) {
+			t.Errorf("Run() with amino JSON %v error = %v; want the omitempty option of a T value rejected", aminoJSON, err)
+		}
+	}
+
+	// The omit_empty option does not apply to values of type parameters.
+	g := NewGenerator("test.go")
+	g.SetPkg("gen", "github.com/CosmWasm/tinyjson/gen")
+	g.OmitEmpty()
+	g.SetTypeParams((*genericPair[typeparam.P0, typeparam.P1])(nil), "K comparable", "V")
+	g.Add((*genericPair[typeparam.P0, typeparam.P1])(nil))
+
+	var out strings.Builder
+	if err := g.Run(&out); err != nil {
+		t.Fatalf("Run() with omit_empty error: %v", err)
+	}
+	if strings.Contains(out.String(), "if true {") {
+		t.Errorf("Run() with omit_empty checks the emptiness of T values:\n%s", out.String())
+	}
+}
+
+func TestDiscriminatingByte(t *testing.T) {
+	for i, test := range []struct {
+		Names []string
+		Pos   int
+	}{
+		{[]string{"denom", "label"}, 0},
+		{[]string{"owner", "other", "oasis"}, 1},
+		{[]string{"code_a", "code_b", "code_c", "dode_a"}, 5},
+	} {
+		names := make([]fieldName, len(test.Names))
+		for j, name := range test.Names {
+			names[j] = fieldName{name: name, index: j}
+		}
+		if got := discriminatingByte(names); got != test.Pos {
+			t.Errorf("[%d] discriminatingByte(%v) = %d; want %d", i, test.Names, got, test.Pos)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package gen
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const pkgTypeParam = "github.com/CosmWasm/tinyjson/gen/typeparam"
+
+// qualifiedNameRegexp matches the package qualified type names in the type arguments of the
+// name of an instantiated generic type, e.g. github.com/CosmWasm/tinyjson/gen/typeparam.P0.
+var qualifiedNameRegexp = regexp.MustCompile(`([\w./-]+)\.(\w+)`)
+
+// TypeParam is a type parameter of a generic type.
+type TypeParam struct {
+	Name       string
+	Constraint string
+}
+
+// SetTypeParams declares the type of given object, a generic type instantiated with the
+// placeholder types of the typeparam package, as generic with the given type parameters, each
+// given as a name followed by its constraint, e.g. "T any". Encoders and decoders generated for
+// the type are generic functions with the same type parameters.
+func (g *Generator) SetTypeParams(obj interface{}, params ...string) {
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	tps := make([]TypeParam, len(params))
+	for i, p := range params {
+		p = strings.TrimSpace(p)
+		if j := strings.IndexAny(p, " \t"); j != -1 {
+			tps[i] = TypeParam{Name: p[:j], Constraint: strings.TrimSpace(p[j:])}
+		} else {
+			tps[i] = TypeParam{Name: p, Constraint: "any"}
+		}
+	}
+	g.typeParams[t] = tps
+}
+
+// typeParamIndex returns the index of the type parameter a placeholder type stands for, or -1
+// if t is not a placeholder type.
+func typeParamIndex(t reflect.Type) int {
+	if t.PkgPath() != pkgTypeParam || !strings.HasPrefix(t.Name(), "P") {
+		return -1
+	}
+	i, err := strconv.Atoi(t.Name()[1:])
+	if err != nil {
+		return -1
+	}
+	return i
+}
+
+// hasTypeParam reports whether t refers to a placeholder type.
+func hasTypeParam(t reflect.Type) bool {
+	return typeParamIndex(t) >= 0 || strings.Contains(t.String(), "typeparam.P")
+}
+
+// typeParamName returns the name of the i-th type parameter of the type being generated.
+func (g *Generator) typeParamName(i int) string {
+	if i < len(g.curTypeParams) {
+		return g.curTypeParams[i].Name
+	}
+	return "P" + strconv.Itoa(i)
+}
+
+// typeParamList returns the type parameter list of encoding/decoding funcs for t, e.g.
+// "[T any]", or an empty string if t is not generic.
+func (g *Generator) typeParamList(t reflect.Type) string {
+	tps := g.typeParams[t]
+	if len(tps) == 0 {
+		return ""
+	}
+	list := make([]string, len(tps))
+	for i, tp := range tps {
+		list[i] = tp.Name + " " + tp.Constraint
+	}
+	return "[" + strings.Join(list, ", ") + "]"
+}
+
+// typeName returns the name of a named type, with the type arguments of an instantiated generic
+// type written as in source code, placeholder types being replaced by type parameter names.
+func (g *Generator) typeName(t reflect.Type) string {
+	name := t.Name()
+	i := strings.IndexByte(name, '[')
+	if i == -1 {
+		return name
+	}
+	return name[:i] + qualifiedNameRegexp.ReplaceAllStringFunc(name[i:], func(s string) string {
+		m := qualifiedNameRegexp.FindStringSubmatch(s)
+		pkgPath, name := m[1], m[2]
+		switch pkgPath {
+		case pkgTypeParam:
+			if i, err := strconv.Atoi(strings.TrimPrefix(name, "P")); err == nil {
+				return g.typeParamName(i)
+			}
+		case g.pkgPath:
+			return name
+		}
+		return g.pkgAlias(pkgPath) + "." + name
+	})
+}
+
+// safeTypeName returns the name of a named type to be used in encoder/decoder names: type
+// arguments are shortened to their package names and placeholder types are dropped.
+func safeTypeName(t reflect.Type) string {
+	name := t.Name()
+	i := strings.IndexByte(name, '[')
+	if i == -1 {
+		return name
+	}
+	return name[:i] + qualifiedNameRegexp.ReplaceAllStringFunc(name[i:], func(s string) string {
+		m := qualifiedNameRegexp.FindStringSubmatch(s)
+		if m[1] == pkgTypeParam {
+			return ""
+		}
+		return path.Base(m[1]) + "." + m[2]
+	})
+}
+
+// checkGeneric verifies that encoding/decoding funcs can be generated for t: types referring to
+// type parameters must be declared generic with SetTypeParams.
+func (g *Generator) checkGeneric(t reflect.Type) error {
+	if g.typeParams[t] == nil && hasTypeParam(t) {
+		return fmt.Errorf("cannot generate encoder/decoder for %v, a generic type must be annotated itself", g.getType(t))
+	}
+	return nil
+}
+
+// checkTypeParamTags verifies that the field tags of a value of the type parameter t only hold
+// options that apply to any type argument: options changing the encoding of a kind of values,
+// e.g. string or hex, are rejected rather than ignored, as the kind is only known at run time.
+func (g *Generator) checkTypeParamTags(t reflect.Type, tags fieldTags) error {
+	var opt string
+	switch {
+	case tags.asString:
+		opt = "string"
+	case tags.hex:
+		opt = "hex"
+	case tags.base64:
+		opt = "base64"
+	case tags.intern:
+		opt = "intern"
+	case tags.noCopy:
+		opt = "nocopy"
+	default:
+		return nil
+	}
+	return fmt.Errorf("tag option %q not supported on values of type parameter %s", opt, g.typeParamName(typeParamIndex(t)))
+}
+
+// genTypeParamEncoder generates an encoder for a value of a type parameter.
+func (g *Generator) genTypeParamEncoder(in string, indent int) {
+	ws := strings.Repeat("  ", indent)
+	fmt.Fprintln(g.out, ws+"tinyjson.EncodeTypeParam(out, "+in+")")
+}
+
+// genTypeParamDecoder generates a decoder for a value of a type parameter.
+func (g *Generator) genTypeParamDecoder(out string, indent int) {
+	ws := strings.Repeat("  ", indent)
+	fmt.Fprintln(g.out, ws+"tinyjson.DecodeTypeParam(in, &"+out+")")
+}
@@ -0,0 +1,128 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+var describerIface = reflect.TypeOf((*tinyjson.Describer)(nil)).Elem()
+
+var typeInfoKinds = map[reflect.Kind]string{
+	reflect.Struct: "tinyjson.KindStruct",
+	reflect.Slice:  "tinyjson.KindSlice",
+	reflect.Array:  "tinyjson.KindArray",
+	reflect.Map:    "tinyjson.KindMap",
+}
+
+// Metadata instructs to generate a static tinyjson.TypeInfo table for every type marshalers are
+// generated for, returned by a TinyJSONTypeInfo method and added to the registry of the
+// tinyjson package.
+func (g *Generator) Metadata() {
+	g.metadata = true
+}
+
+// genTypeInfo generates the metadata table of t, its TinyJSONTypeInfo method and the init func
+// registering it.
+func (g *Generator) genTypeInfo(t reflect.Type) error {
+	kind, ok := typeInfoKinds[t.Kind()]
+	if !ok {
+		return fmt.Errorf("cannot generate metadata for %v, not a struct/slice/array/map type", t)
+	}
+
+	vname := g.functionName("TypeInfo", t)
+	typ := g.getType(t)
+
+	fmt.Fprintln(g.out, "var "+vname+" = tinyjson.TypeInfo{")
+	fmt.Fprintf(g.out, "  Package: %q,\n", fixPkgPathVendoring(t.PkgPath()))
+	fmt.Fprintf(g.out, "  Name: %q,\n", g.typeName(t))
+	fmt.Fprintln(g.out, "  Kind: "+kind+",")
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fs, err := getStructFields(t)
+		if err != nil {
+			return fmt.Errorf("cannot generate metadata for %v: %v", t, err)
+		}
+		if g.aminoJSON {
+			sort.SliceStable(fs, func(i, j int) bool {
+				return g.jsonFieldName(t, fs[i]) < g.jsonFieldName(t, fs[j])
+			})
+		}
+		fmt.Fprintln(g.out, "  Fields: []tinyjson.FieldInfo{")
+		for _, f := range fs {
+			g.genFieldInfo(t, f)
+		}
+		fmt.Fprintln(g.out, "  },")
+	default:
+		if elem := g.describer(t.Elem()); elem != "" {
+			fmt.Fprintln(g.out, "  Elem: "+elem+",")
+		}
+	}
+	fmt.Fprintln(g.out, "}")
+	fmt.Fprintln(g.out)
+
+	fmt.Fprintln(g.out, "// TinyJSONTypeInfo supports tinyjson.Describer interface")
+	fmt.Fprintln(g.out, "func (v "+typ+") TinyJSONTypeInfo() *tinyjson.TypeInfo {")
+	fmt.Fprintln(g.out, "  return &"+vname)
+	fmt.Fprintln(g.out, "}")
+	fmt.Fprintln(g.out)
+
+	fmt.Fprintln(g.out, "func init() {")
+	fmt.Fprintln(g.out, "  tinyjson.RegisterTypeInfo(&"+vname+")")
+	fmt.Fprintln(g.out, "}")
+	return nil
+}
+
+// genFieldInfo generates the metadata of a struct field.
+func (g *Generator) genFieldInfo(t reflect.Type, f reflect.StructField) {
+	tags := parseFieldTags(f)
+	if tags.omit {
+		return
+	}
+
+	fmt.Fprintln(g.out, "    {")
+	fmt.Fprintf(g.out, "      Name: %q,\n", f.Name)
+	fmt.Fprintf(g.out, "      JSONName: %q,\n", g.jsonFieldName(t, f))
+	fmt.Fprintf(g.out, "      Type: %q,\n", g.getType(f.Type))
+	if (tags.omitEmpty || g.omitEmpty) && !tags.noOmitEmpty && typeParamIndex(f.Type) < 0 {
+		fmt.Fprintln(g.out, "      OmitEmpty: true,")
+	}
+	if tags.required {
+		fmt.Fprintln(g.out, "      Required: true,")
+	}
+	if tags.asString {
+		fmt.Fprintln(g.out, "      AsString: true,")
+	}
+	if elem := g.describer(f.Type); elem != "" {
+		fmt.Fprintln(g.out, "      Elem: "+elem+",")
+	}
+	fmt.Fprintln(g.out, "    },")
+}
+
+// describer returns the zero value of the type t holds, through pointers, slices, arrays and
+// maps, as a tinyjson.Describer, or an empty string if that type is not one.
+func (g *Generator) describer(t reflect.Type) string {
+	for t.Name() == "" {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return ""
+		}
+	}
+	if hasTypeParam(t) || !t.Implements(describerIface) {
+		return ""
+	}
+
+	// A zero value, unlike a method value, does not make the tables of recursive types
+	// depend on themselves during package initialization.
+	switch t.Kind() {
+	case reflect.Struct, reflect.Array:
+		return g.getType(t) + "{}"
+	default:
+		return "*new(" + g.getType(t) + ")"
+	}
+}
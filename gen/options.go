@@ -0,0 +1,68 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// typeOptions are the generator options that can be overridden per type.
+type typeOptions struct {
+	fieldNamer               FieldNamer
+	omitEmpty                bool
+	disallowUnknownFields    bool
+	skipMemberNameUnescaping bool
+}
+
+// SetTypeOptions sets options for the type of given object, overriding the ones of the
+// generator, as given after a tinyjson:json comment:
+//
+//	snake_case, lower_camel_case, kebab_case,      member naming
+//	screaming_snake_case, pascal_case, go_case     (go_case keeps the Go field names)
+//	omit_empty                                     omitempty by default
+//	disallow_unknown_fields (or disallow_unknown)  fail on unknown members
+//	disable_members_unescape                       don't unescape member names
+//
+// The boolean options are turned off with a no_ prefix, e.g. no_omit_empty. Unknown options
+// make Run fail.
+func (g *Generator) SetTypeOptions(obj interface{}, options ...string) {
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	g.optionsByType[t] = options
+}
+
+// set applies the options set for type t.
+func (o *typeOptions) set(t reflect.Type, options []string) error {
+	for _, option := range options {
+		name, value := option, true
+		if strings.HasPrefix(name, "no_") {
+			name, value = name[len("no_"):], false
+		}
+
+		switch {
+		case name == "omit_empty":
+			o.omitEmpty = value
+		case name == "disallow_unknown_fields" || name == "disallow_unknown":
+			o.disallowUnknownFields = value
+		case name == "disable_members_unescape":
+			o.skipMemberNameUnescaping = value
+		case option == "snake_case":
+			o.fieldNamer = SnakeCaseFieldNamer{}
+		case option == "lower_camel_case":
+			o.fieldNamer = LowerCamelCaseFieldNamer{}
+		case option == "kebab_case":
+			o.fieldNamer = KebabCaseFieldNamer{}
+		case option == "screaming_snake_case":
+			o.fieldNamer = ScreamingSnakeCaseFieldNamer{}
+		case option == "pascal_case":
+			o.fieldNamer = PascalCaseFieldNamer{}
+		case option == "go_case":
+			o.fieldNamer = DefaultFieldNamer{}
+		default:
+			return fmt.Errorf("%v: unknown tinyjson:json option %q", t, option)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// isProtoWellKnown returns true if t has the shape of the generated Go type for the
+// google.protobuf well-known type with the given name. Types are recognized by name and fields
+// so that the types generated by both protoc-gen-go and protoc-gen-gogo are supported.
+func isProtoWellKnown(t reflect.Type, name string) bool {
+	if t.Kind() != reflect.Struct || t.Name() != name {
+		return false
+	}
+
+	hasField := func(name string, k reflect.Kind) bool {
+		f, ok := t.FieldByName(name)
+		return ok && f.Type.Kind() == k
+	}
+	switch name {
+	case "Timestamp", "Duration":
+		return hasField("Seconds", reflect.Int64) && hasField("Nanos", reflect.Int32)
+	case "Any":
+		return hasField("TypeUrl", reflect.String) && hasField("Value", reflect.Slice)
+	}
+	return false
+}
+
+// isProtoEnum returns true if the field of type t is a protobuf enum, i.e. it is tagged as one
+// and its type has a String method returning the name of the value.
+func isProtoEnum(t reflect.Type, tags fieldTags) bool {
+	stringerIface := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	return tags.protoEnum && t.Kind() == reflect.Int32 && t.Name() != "" && t.Implements(stringerIface)
+}
+
+// genProto3Encoder generates the proto3 JSON encoding code for well-known types and enums. It
+// returns false if t needs no special handling.
+func (g *Generator) genProto3Encoder(t reflect.Type, in string, tags fieldTags, indent int) bool {
+	ws := strings.Repeat("  ", indent)
+
+	switch {
+	case isProtoWellKnown(t, "Timestamp"):
+		fmt.Fprintln(g.out, ws+"out.ProtoTimestamp(("+in+").Seconds, ("+in+").Nanos)")
+	case isProtoWellKnown(t, "Duration"):
+		fmt.Fprintln(g.out, ws+"out.ProtoDuration(("+in+").Seconds, ("+in+").Nanos)")
+	case isProtoWellKnown(t, "Any"):
+		fmt.Fprintln(g.out, ws+"tinyjson.EncodeProtoAny(out, ("+in+").TypeUrl, ("+in+").Value)")
+	case isProtoEnum(t, tags):
+		fmt.Fprintln(g.out, ws+"out.String(("+in+").String())")
+	default:
+		return false
+	}
+	return true
+}
+
+// genProto3Decoder generates the proto3 JSON decoding code for well-known types and enums. It
+// returns false if t needs no special handling.
+func (g *Generator) genProto3Decoder(t reflect.Type, out string, tags fieldTags, indent int) bool {
+	ws := strings.Repeat("  ", indent)
+
+	switch {
+	case isProtoWellKnown(t, "Timestamp"):
+		fmt.Fprintln(g.out, ws+"("+out+").Seconds, ("+out+").Nanos = in.ProtoTimestamp()")
+	case isProtoWellKnown(t, "Duration"):
+		fmt.Fprintln(g.out, ws+"("+out+").Seconds, ("+out+").Nanos = in.ProtoDuration()")
+	case isProtoWellKnown(t, "Any"):
+		fmt.Fprintln(g.out, ws+"("+out+").TypeUrl, ("+out+").Value = tinyjson.DecodeProtoAny(in)")
+	case isProtoEnum(t, tags):
+		nameVar := g.uniqueVarName()
+		valueVar := g.uniqueVarName()
+
+		// Enums are accepted both by name and by number.
+		fmt.Fprintln(g.out, ws+"if in.IsString() {")
+		fmt.Fprintln(g.out, ws+"  "+nameVar+" := in.UnsafeString()")
+		fmt.Fprintln(g.out, ws+"  if "+valueVar+", ok := "+g.getType(t)+"_value["+nameVar+"]; ok {")
+		fmt.Fprintln(g.out, ws+"    "+out+" = "+g.getType(t)+"("+valueVar+")")
+		fmt.Fprintln(g.out, ws+"  } else if in.Ok() {")
+		fmt.Fprintln(g.out, ws+"    in.AddNonFatalError(jlexer.NewError(\"unknown enum value \" + "+nameVar+"))")
+		fmt.Fprintln(g.out, ws+"  }")
+		fmt.Fprintln(g.out, ws+"} else {")
+		fmt.Fprintln(g.out, ws+"  "+out+" = "+g.getType(t)+"(in.Int32())")
+		fmt.Fprintln(g.out, ws+"}")
+	default:
+		return false
+	}
+	return true
+}
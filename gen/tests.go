@@ -0,0 +1,175 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+const pkgDiffTest = "github.com/CosmWasm/tinyjson/difftest"
+
+// RunTests outputs to out the code of tests checking that the types marshalers were requested
+// for with Add are encoded like encoding/json encodes them, after Run. Each type gets a table
+// test, over sample values with typical, extreme, empty and nil fields, and a fuzz test,
+// decoding arbitrary JSON. Both check that the encoding round-trips and compare it with the one
+// of encoding/json, unless the options of the type make them differ, e.g. Amino JSON or member
+// naming. Generic types get no tests.
+func (g *Generator) RunTests(out io.Writer) error {
+	defaults := g.typeOptions
+	defer func() { g.typeOptions = defaults }()
+
+	var types []reflect.Type
+	for t := range g.marshalers {
+		if g.typeParams[t] == nil {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name() < types[j].Name() })
+
+	if g.buildTags != "" {
+		fmt.Fprintln(out, "// +build ", g.buildTags)
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintln(out, "// Code generated by tinyjson for marshaling/unmarshaling. DO NOT EDIT.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "package ", g.pkgName)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "import (")
+	fmt.Fprintln(out, `  "testing"`)
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "  %q\n", pkgTinyJSON)
+	fmt.Fprintf(out, "  %q\n", pkgDiffTest)
+	fmt.Fprintln(out, ")")
+
+	for _, t := range types {
+		g.typeOptions = defaults
+		if err := g.typeOptions.set(t, g.optionsByType[t]); err != nil {
+			return err
+		}
+
+		name := t.Name()
+		std := "nil"
+		fmt.Fprintln(out)
+		if reason := g.stdIncompatibility(t); reason != "" {
+			fmt.Fprintf(out, "// %s is only checked to round-trip: %s\n", name, reason)
+			fmt.Fprintln(out, "// makes its encoding differ from encoding/json.")
+		} else {
+			fmt.Fprintf(out, "// tinyjsonStd%s is %s without its methods, encoded by encoding/json.\n", name, name)
+			fmt.Fprintf(out, "type tinyjsonStd%s %s\n", name, name)
+			fmt.Fprintln(out)
+			std = "(*tinyjsonStd" + name + ")(v)"
+		}
+
+		fmt.Fprintf(out, "func TestTinyJSON%s(t *testing.T) {\n", name)
+		fmt.Fprintf(out, "  for _, c := range difftest.Cases(new(%s)) {\n", name)
+		fmt.Fprintln(out, "    t.Run(c.Name, func(t *testing.T) {")
+		fmt.Fprintf(out, "      v := c.Value.(*%s)\n", name)
+		fmt.Fprintf(out, "      difftest.Check(t, v, %s)\n", std)
+		fmt.Fprintln(out, "    })")
+		fmt.Fprintln(out, "  }")
+		fmt.Fprintln(out, "}")
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "func FuzzTinyJSON%s(f *testing.F) {\n", name)
+		fmt.Fprintf(out, "  difftest.Seed(f, new(%s))\n", name)
+		fmt.Fprintln(out, "  f.Fuzz(func(t *testing.T, data []byte) {")
+		fmt.Fprintf(out, "    v := new(%s)\n", name)
+		fmt.Fprintln(out, "    if tinyjson.Unmarshal(data, v) != nil {")
+		fmt.Fprintln(out, "      t.Skip()")
+		fmt.Fprintln(out, "    }")
+		fmt.Fprintf(out, "    difftest.Check(t, v, %s)\n", std)
+		fmt.Fprintln(out, "  })")
+		fmt.Fprintln(out, "}")
+	}
+	return nil
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	optionalType      = reflect.TypeOf((*tinyjson.Optional)(nil)).Elem()
+)
+
+// stdIncompatibility returns what makes the encoding of the type t differ from the one of
+// encoding/json, if anything.
+func (g *Generator) stdIncompatibility(t reflect.Type) string {
+	switch {
+	case g.aminoJSON:
+		return "Amino JSON"
+	case g.proto3JSON:
+		return "Proto3 JSON"
+	case g.omitEmpty:
+		return "omit_empty"
+	}
+	if _, ok := g.fieldNamer.(DefaultFieldNamer); !ok {
+		return "member naming"
+	}
+	return g.incompatibleElem(t, map[reflect.Type]bool{})
+}
+
+// incompatibleType returns what makes the encoding of the type t, used by another one, differ
+// from the one of encoding/json. Types with JSON marshalers, such as the generated ones, are
+// encoded by them with both.
+func (g *Generator) incompatibleType(t reflect.Type, seen map[reflect.Type]bool) string {
+	if seen[t] {
+		return ""
+	}
+	seen[t] = true
+	if g.hasCodec(t) {
+		return "the codec of " + t.String()
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return ""
+	}
+	return g.incompatibleElem(t, seen)
+}
+
+// incompatibleElem returns what makes the encoding of the elements or fields of the type t differ
+// from the one of encoding/json.
+func (g *Generator) incompatibleElem(t reflect.Type, seen map[reflect.Type]bool) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.incompatibleType(t.Elem(), seen)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && g.simpleBytes {
+			return "simple_bytes"
+		}
+		return g.incompatibleType(t.Elem(), seen)
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Elem().Name() == "uint8" {
+			return "the byte array " + t.String()
+		}
+		return g.incompatibleType(t.Elem(), seen)
+	case reflect.Map:
+		if reason := g.incompatibleType(t.Key(), seen); reason != "" {
+			return reason
+		}
+		return g.incompatibleType(t.Elem(), seen)
+	case reflect.Struct:
+		fields, err := getStructFields(t)
+		if err != nil {
+			return ""
+		}
+		for _, f := range fields {
+			tags := parseFieldTags(f)
+			switch {
+			case tags.omit:
+				continue
+			case tags.hex:
+				return "the hex tag of " + t.Name() + "." + f.Name
+			case tags.nilSliceAsEmpty:
+				return "the emptyslice tag of " + t.Name() + "." + f.Name
+			case tags.omitEmpty && reflect.PtrTo(f.Type).Implements(optionalType):
+				return "omitempty on the optional " + t.Name() + "." + f.Name
+			case g.renamedField(t, f) != "":
+				return "the renaming of " + t.Name() + "." + f.Name
+			}
+			if reason := g.incompatibleType(f.Type, seen); reason != "" {
+				return reason
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,18 @@
+// Package typeparam provides the placeholder types generic types are instantiated with when
+// bootstrapping the generator: the i-th type parameter of a generic type is instantiated with
+// Pi, which the generator maps back to the name of the parameter.
+package typeparam
+
+type (
+	P0 struct{}
+	P1 struct{}
+	P2 struct{}
+	P3 struct{}
+	P4 struct{}
+	P5 struct{}
+	P6 struct{}
+	P7 struct{}
+)
+
+// Max is the maximum number of type parameters of a generic type.
+const Max = 8
@@ -1,5 +1,5 @@
 module github.com/CosmWasm/tinyjson
 
-go 1.16
+go 1.18
 
 require github.com/josharian/intern v1.0.0
@@ -26,6 +26,12 @@ type MarshalerUnmarshaler interface {
 	Unmarshaler
 }
 
+// Appender is implemented by generated types, which append their JSON encoding to a
+// caller-supplied slice.
+type Appender interface {
+	AppendTinyJSON(dst []byte) []byte
+}
+
 // Optional defines an undefined-test method for a type to integrate with 'omitempty' logic.
 type Optional interface {
 	IsDefined() bool
@@ -57,6 +63,23 @@ func Marshal(v Marshaler) ([]byte, error) {
 	return w.BuildBytes()
 }
 
+// Append appends the JSON encoding of v to dst and returns the extended slice. The data is
+// written directly to dst, which is grown like with append if it is too small, without using
+// pooled chunks or copying it. On error dst is returned unchanged.
+func Append(dst []byte, v Marshaler) ([]byte, error) {
+	if isNilInterface(v) {
+		return append(dst, nullBytes...), nil
+	}
+
+	w := jwriter.Writer{}
+	w.AppendTo(dst)
+	v.MarshalTinyJSON(&w)
+	if w.Error != nil {
+		return dst, w.Error
+	}
+	return w.Buffer.Buf, nil
+}
+
 // MarshalToWriter marshals the data to an io.Writer.
 func MarshalToWriter(v Marshaler, w io.Writer) (written int, err error) {
 	if isNilInterface(v) {
@@ -75,6 +98,24 @@ func Unmarshal(data []byte, v Unmarshaler) error {
 	return l.Error()
 }
 
+// UnmarshalAllErrors decodes the JSON in data into the object like Unmarshal, but continues past
+// recoverable errors such as type mismatches and missing required or unknown fields. All errors
+// are returned at once as a *jlexer.MultiError listing each one with its JSON path.
+func UnmarshalAllErrors(data []byte, v Unmarshaler) error {
+	l := jlexer.Lexer{Data: data, UseMultipleErrors: true}
+	v.UnmarshalTinyJSON(&l)
+	return l.Errors()
+}
+
+// UnmarshalReset decodes the JSON in data into the object like Unmarshal, but first resets the
+// structs, maps and arrays decoded into to their zero value instead of merging the JSON into
+// them. The capacity of slices is reused.
+func UnmarshalReset(data []byte, v Unmarshaler) error {
+	l := jlexer.Lexer{Data: data, ResetOnDecode: true}
+	v.UnmarshalTinyJSON(&l)
+	return l.Error()
+}
+
 // UnmarshalFromReader reads all the data in the reader and decodes as JSON into the object.
 func UnmarshalFromReader(r io.Reader, v Unmarshaler) error {
 	data, err := ioutil.ReadAll(r)
@@ -0,0 +1,27 @@
+// Package prototime holds the ranges of the google.protobuf.Timestamp and Duration well-known
+// types, shared by jlexer and jwriter, which reject values outside of them like protojson.
+package prototime
+
+const (
+	// MinTimestamp is the smallest Timestamp, 0001-01-01T00:00:00Z, in seconds since the Unix
+	// epoch.
+	MinTimestamp = -62135596800
+	// MaxTimestamp is the largest Timestamp, 9999-12-31T23:59:59Z, in seconds since the Unix
+	// epoch.
+	MaxTimestamp = 253402300799
+	// MaxDuration is the largest Duration, about 10,000 years, in seconds. The smallest one is
+	// -MaxDuration.
+	MaxDuration = 315576000000
+)
+
+// ValidTimestamp reports whether seconds and nanos are a valid Timestamp.
+func ValidTimestamp(seconds int64, nanos int32) bool {
+	return seconds >= MinTimestamp && seconds <= MaxTimestamp && nanos >= 0 && nanos < 1e9
+}
+
+// ValidDuration reports whether seconds and nanos are a valid Duration: both in range and, unless
+// one of them is zero, of the same sign.
+func ValidDuration(seconds int64, nanos int32) bool {
+	return seconds >= -MaxDuration && seconds <= MaxDuration && nanos > -1e9 && nanos < 1e9 &&
+		!(seconds > 0 && nanos < 0) && !(seconds < 0 && nanos > 0)
+}
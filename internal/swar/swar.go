@@ -0,0 +1,16 @@
+// Package swar provides the word-at-a-time (SWAR) helpers shared by jlexer and jwriter to check
+// 8 bytes of input per step, portable and without unsafe.
+package swar
+
+const (
+	// Ones has the low bit of every byte set: multiplied by a byte, it repeats it 8 times.
+	Ones = 0x0101010101010101
+	// Highs has the high bit of every byte set.
+	Highs = 0x8080808080808080
+)
+
+// ZeroBytes has the high bit set in the bytes of the result corresponding to zero bytes of x;
+// bytes above the first zero byte may be marked spuriously.
+func ZeroBytes(x uint64) uint64 {
+	return (x - Ones) &^ x & Highs
+}
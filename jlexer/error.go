@@ -1,6 +1,36 @@
 package jlexer
 
-import "strconv"
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSnippetLen is the maximum length of the source line excerpt kept in a LexerError.
+const maxSnippetLen = 80
+
+// Error kinds wrapped by LexerError, to be checked with errors.Is.
+var (
+	// ErrSyntax is the kind of errors for malformed JSON.
+	ErrSyntax = errors.New("syntax error")
+	// ErrTypeMismatch is the kind of errors for JSON values of the wrong type, e.g. a number
+	// where a string is expected.
+	ErrTypeMismatch = errors.New("type mismatch")
+	// ErrOverflow is the kind of errors for numbers out of the range of their Go type.
+	ErrOverflow = errors.New("number out of range")
+	// ErrInvalidValue is the kind of errors for values of the right JSON type but with invalid
+	// contents, e.g. malformed base64 data.
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrTrailingData is the kind of errors for data following the top-level value.
+	ErrTrailingData = errors.New("trailing data")
+	// ErrUnknownField is the kind of errors for object members that match no struct field when
+	// unknown fields are disallowed.
+	ErrUnknownField = errors.New("unknown field")
+	// ErrRequiredField is the kind of errors for required fields missing from an object.
+	ErrRequiredField = errors.New("required field missing")
+)
 
 // LexerError implements the error interface and represents all possible errors that can be
 // generated during parsing the JSON data.
@@ -8,13 +38,235 @@ type LexerError struct {
 	Reason string
 	Offset int
 	Data   string
+
+	// Err is the kind of the error, e.g. ErrSyntax, or the error passed to AddNonFatalError.
+	Err error
+
+	// Path is the JSON path of the value the error is about, e.g. $.funds[1].amount.
+	Path string
+
+	// Line and Column are the 1-based position of Offset in the input, the column counting
+	// characters rather than bytes. They are zero if the position is unknown.
+	Line   int
+	Column int
+
+	snippet     string // Excerpt of the line containing Offset.
+	snippetCol  int    // Characters in snippet before Offset.
+	snippetTrim bool   // Whether snippet starts in the middle of the line.
 }
 
 func (l *LexerError) Error() string {
-	msg := "parse error: " + l.Reason + " near offset " + strconv.Itoa(l.Offset) + "of '" + l.Data + "'"
+	msg := "parse error: " + l.Reason + " near offset " + strconv.Itoa(l.Offset)
+	if l.Data != "" {
+		msg += " of '" + l.Data + "'"
+	}
+	return msg
+}
+
+// Unwrap returns the kind of the error.
+func (l *LexerError) Unwrap() error {
+	return l.Err
+}
+
+// Verbose returns a multi-line description of the error with its line and column and an
+// excerpt of the offending line, a caret pointing at the error position:
+//
+//	parse error: expected string at line 2, column 11
+//	  2 |   "name": 12x,
+//	    |           ^
+func (l *LexerError) Verbose() string {
+	if l.Line == 0 {
+		return l.Error()
+	}
+
+	var b strings.Builder
+	b.WriteString("parse error: " + l.Reason + " at line " + strconv.Itoa(l.Line) + ", column " + strconv.Itoa(l.Column))
+
+	lineNo := strconv.Itoa(l.Line)
+	gutter := strings.Repeat(" ", len(lineNo))
+	b.WriteString("\n  " + lineNo + " | ")
+	prefix := ""
+	if l.snippetTrim {
+		prefix = "..."
+	}
+	b.WriteString(prefix + l.snippet)
+	b.WriteString("\n  " + gutter + " | " + strings.Repeat(" ", len(prefix)))
+	i := 0
+	for _, c := range l.snippet {
+		if i == l.snippetCol {
+			break
+		}
+		// Keep tabs so that the caret lines up with the excerpt.
+		if c == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+		i++
+	}
+	b.WriteByte('^')
+	return b.String()
+}
+
+// locator finds the positions of errors in a single forward pass over the input, resuming
+// where the previous error was located.
+type locator struct {
+	data      []byte // Input the state below is about.
+	offset    int    // Offset of the last located error.
+	line      int    // Line of offset.
+	lineStart int    // Offset of the start of line.
+	column    int    // Column of offset.
+	path      pathScanner
+}
+
+// byOffset sorts errors by increasing offsets.
+type byOffset []*LexerError
+
+func (e byOffset) Len() int           { return len(e) }
+func (e byOffset) Less(i, j int) bool { return e[i].Offset < e[j].Offset }
+func (e byOffset) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+
+// locate fills in the line, column, path and source excerpt of the errors not located yet. The
+// errors collected in multiple errors mode are located when they are returned rather than when
+// they are added, so that decoding with many errors scans the input once.
+func (r *Lexer) locate(errs ...*LexerError) {
+	var pending byOffset
+	for _, err := range errs {
+		if err.Line == 0 && err.Offset >= 0 && err.Offset <= len(r.Data) {
+			pending = append(pending, err)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+	sort.Sort(pending)
+
+	loc := &r.loc
+	if len(loc.data) != len(r.Data) || len(r.Data) > 0 && &loc.data[0] != &r.Data[0] || pending[0].Offset < loc.offset {
+		*loc = locator{data: r.Data, line: 1, column: 1}
+	}
+	for _, err := range pending {
+		loc.advance(err.Offset)
+		loc.fill(err)
+	}
+}
+
+// advance moves the position of loc forward to offset.
+func (loc *locator) advance(offset int) {
+	data := loc.data
+	lineStart := loc.lineStart
+	for i := loc.offset; i < offset; i++ {
+		if data[i] == '\n' {
+			loc.line++
+			lineStart = i + 1
+		}
+	}
+	if lineStart != loc.lineStart {
+		loc.lineStart = lineStart
+		loc.column = utf8.RuneCount(data[lineStart:offset]) + 1
+	} else {
+		loc.column += utf8.RuneCount(data[loc.offset:offset])
+	}
+	loc.offset = offset
+}
+
+// fill sets the position of err, at the offset of loc.
+func (loc *locator) fill(err *LexerError) {
+	data, offset, lineStart := loc.data, loc.offset, loc.lineStart
+	err.Line = loc.line
+	err.Column = loc.column
+
+	// Only look for the end of the line as far as the excerpt may go.
+	lineEnd := offset
+	for lineEnd < len(data) && lineEnd-offset <= maxSnippetLen && data[lineEnd] != '\n' && data[lineEnd] != '\r' {
+		lineEnd++
+	}
+
+	// Keep at most maxSnippetLen bytes around the offset, not splitting characters.
+	from, to := lineStart, lineEnd
+	if to-from > maxSnippetLen {
+		from = offset - maxSnippetLen/2
+		if from < lineStart {
+			from = lineStart
+		}
+		for from > lineStart && !utf8.RuneStart(data[from]) {
+			from--
+		}
+		to = from + maxSnippetLen
+		if to > lineEnd {
+			to = lineEnd
+		}
+		for to > from && to < lineEnd && !utf8.RuneStart(data[to]) {
+			to--
+		}
+	}
+	err.Path = loc.path.pathAt(data, offset)
+	err.snippet = string(data[from:to])
+	err.snippetCol = utf8.RuneCount(data[from:offset])
+	err.snippetTrim = from > lineStart
+}
+
+// MultiError aggregates all errors met while decoding in multiple errors mode.
+type MultiError struct {
+	Errors []*LexerError
+}
+
+// Error lists every error with its JSON path, one per line.
+func (m *MultiError) Error() string {
+	if len(m.Errors) == 1 {
+		return m.Errors[0].summary()
+	}
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(len(m.Errors)) + " errors:")
+	for _, e := range m.Errors {
+		b.WriteString("\n\t" + e.summary())
+	}
+	return b.String()
+}
+
+// Unwrap returns the aggregated errors, for use with errors.Is and errors.As.
+func (m *MultiError) Unwrap() []error {
+	errs := make([]error, len(m.Errors))
+	for i, e := range m.Errors {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Is reports whether any of the aggregated errors matches target, for Go versions whose
+// errors.Is does not support multiple wrapped errors.
+func (m *MultiError) Is(target error) bool {
+	for _, e := range m.Errors {
+		if e == target || e.Err == target {
+			return true
+		}
+		if x, ok := e.Err.(interface{ Is(error) bool }); ok && x.Is(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// summary describes the error in a single line starting with its JSON path.
+func (l *LexerError) summary() string {
+	msg := l.Reason
+	if l.Path != "" {
+		msg = l.Path + ": " + msg
+	}
+	if l.Line != 0 {
+		msg += " (line " + strconv.Itoa(l.Line) + ", column " + strconv.Itoa(l.Column) + ")"
+	}
 	return msg
 }
 
+// numberError returns the kind of a strconv number parsing error.
+func numberError(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrOverflow
+	}
+	return ErrTypeMismatch
+}
+
 // This is a (temporary?) helper to use in place of errors.New
 func NewError(msg string) error {
 	return myError{msg: msg}
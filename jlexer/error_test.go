@@ -0,0 +1,133 @@
+package jlexer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLexerErrorPosition(t *testing.T) {
+	data := "{\n  \"a\": 1,\n\t\"b\": x\n}"
+	l := Lexer{Data: []byte(data)}
+	l.Delim('{')
+	l.UnsafeFieldName(false)
+	l.WantColon()
+	l.Int()
+	l.WantComma()
+	l.UnsafeFieldName(false)
+	l.WantColon()
+	l.Int()
+
+	err, ok := l.Error().(*LexerError)
+	if !ok {
+		t.Fatalf("Error() = %#v; want *LexerError", l.Error())
+	}
+	if err.Offset != 18 || err.Line != 3 || err.Column != 7 {
+		t.Errorf("Offset, Line, Column = %d, %d, %d; want 18, 3, 7", err.Offset, err.Line, err.Column)
+	}
+	if got, want := err.Error(), "parse error: syntax error near offset 18 of 'x\n}'"; got != want {
+		t.Errorf("Error() = %q; want %q", got, want)
+	}
+	want := "parse error: syntax error at line 3, column 7\n" +
+		"  3 | \t\"b\": x\n" +
+		"    | \t     ^"
+	if got := err.Verbose(); got != want {
+		t.Errorf("Verbose() = %q; want %q", got, want)
+	}
+}
+
+func TestLexerErrorLongLine(t *testing.T) {
+	data := `["` + strings.Repeat("é", 100) + `", tru]`
+	l := Lexer{Data: []byte(data)}
+	l.Delim('[')
+	_ = l.String()
+	l.WantComma()
+	l.Bool()
+
+	err, ok := l.Error().(*LexerError)
+	if !ok {
+		t.Fatalf("Error() = %#v; want *LexerError", l.Error())
+	}
+	if err.Line != 1 || err.Column != 106 {
+		t.Errorf("Line, Column = %d, %d; want 1, 106", err.Line, err.Column)
+	}
+	lines := strings.Split(err.Verbose(), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "  1 | ...") {
+		t.Fatalf("Verbose() = %q; want a trimmed excerpt", err.Verbose())
+	}
+	excerpt := []rune(strings.TrimPrefix(lines[1], "  1 | "))
+	caret := strings.Index(strings.TrimPrefix(lines[2], "    | "), "^")
+	if caret < 0 || caret >= len(excerpt) || excerpt[caret] != 't' {
+		t.Errorf("Verbose() = %q; caret does not point at the error", err.Verbose())
+	}
+}
+
+func TestLexerErrorInvalidUTF8(t *testing.T) {
+	// A long line of continuation bytes, with no character start to trim the excerpt at.
+	l := Lexer{Data: []byte(strings.Repeat("\xa1", 100))}
+	l.Delim('{')
+
+	err, ok := l.Error().(*LexerError)
+	if !ok {
+		t.Fatalf("Error() = %#v; want *LexerError", l.Error())
+	}
+	if err.Line != 1 || err.Column != 1 {
+		t.Errorf("Line, Column = %d, %d; want 1, 1", err.Line, err.Column)
+	}
+}
+
+func TestPathAt(t *testing.T) {
+	data := `{"a": [1, {"b": "x", "c d": [true]}], "e": {}}`
+	for i, test := range []struct {
+		offset int
+		want   string
+	}{
+		{offset: 0, want: "$"},
+		{offset: 6, want: "$.a"},
+		{offset: 7, want: "$.a[0]"},
+		{offset: 10, want: "$.a[1]"},
+		{offset: 16, want: "$.a[1].b"},
+		{offset: 19, want: "$.a[1].b"},
+		{offset: 30, want: `$.a[1]["c d"][0]`},
+		{offset: 44, want: "$.e"},
+		{offset: len(data), want: "$"},
+	} {
+		if got := PathAt([]byte(data), test.offset); got != test.want {
+			t.Errorf("[%d] PathAt(%d) = %s; want %s", i, test.offset, got, test.want)
+		}
+	}
+}
+
+func TestLexerErrorsLocatedOnce(t *testing.T) {
+	data := "[\"é\", 1,\n  {\"a\": \"x\", \"b\": [2, \"y\"]},\n  \"z\", 3]"
+	l := Lexer{Data: []byte(data), UseMultipleErrors: true}
+	l.Delim('[')
+	for _, skip := range []bool{false, false, true, false, false} {
+		if skip {
+			l.SkipRecursive()
+		} else {
+			_ = l.Int()
+		}
+		l.WantComma()
+	}
+	// Errors added out of order are located as well.
+	l.AddNonFatalError(&LexerError{Offset: 18, Reason: "late", Err: ErrInvalidValue})
+
+	errs := l.GetNonFatalErrors()
+	if len(errs) != 3 {
+		t.Fatalf("GetNonFatalErrors() = %v; want 3 errors", errs)
+	}
+	for _, err := range errs {
+		// A new lexer locates err from the start of the data.
+		want := &LexerError{Offset: err.Offset, Reason: err.Reason, Err: err.Err}
+		fresh := Lexer{Data: []byte(data)}
+		fresh.AddError(want)
+		fresh.Error()
+		if err.Line != want.Line || err.Column != want.Column || err.Path != want.Path || err.Verbose() != want.Verbose() {
+			t.Errorf("error at offset %d: line %d, column %d, path %s; want %d, %d, %s",
+				err.Offset, err.Line, err.Column, err.Path, want.Line, want.Column, want.Path)
+		}
+	}
+	if errs[2].Line != 2 || errs[2].Path != "$[2].a" {
+		t.Errorf("late error: line %d, path %s; want 2, $[2].a", errs[2].Line, errs[2].Path)
+	}
+}
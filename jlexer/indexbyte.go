@@ -0,0 +1,9 @@
+// This file is included to the build unless the tinygo or tinyjson_swar build tag is set.
+
+//+build !tinygo,!tinyjson_swar
+
+package jlexer
+
+// fastIndexByte tells whether bytes.IndexByte is vectorized, finding the end of strings faster
+// than SWAR checks.
+const fastIndexByte = true
@@ -0,0 +1,10 @@
+// This file is included to the build when compiling with TinyGo, or with the tinyjson_swar build
+// tag, which forces the SWAR checks to test and benchmark them with the gc compiler.
+
+//+build tinygo tinyjson_swar
+
+package jlexer
+
+// fastIndexByte tells whether bytes.IndexByte is vectorized. It is a byte loop under TinyGo,
+// where SWAR checks find the end of strings faster.
+const fastIndexByte = false
@@ -13,6 +13,7 @@ import (
 	"unicode/utf16"
 	"unicode/utf8"
 
+	"github.com/CosmWasm/tinyjson/buffer"
 	"github.com/josharian/intern"
 )
 
@@ -49,9 +50,13 @@ type Lexer struct {
 	firstElement bool // Whether current element is the first in array or an object.
 	wantSep      byte // A comma or a colon character, which need to occur before a token.
 
-	UseMultipleErrors bool          // If we want to use multiple errors.
-	fatalError        error         // Fatal error occurred during lexing. It is usually a syntax error.
-	multipleErrors    []*LexerError // Semantic errors occurred during lexing. Marshalling will be continued after finding this errors.
+	UseMultipleErrors bool             // If we want to use multiple errors.
+	AddrPrefix        string           // Human-readable part expected for decoded tinyjson.Addr values.
+	Alloc             buffer.Allocator // Allocator for decoded strings and byte slices, if not nil.
+	ResetOnDecode     bool             // Whether decoders reset values to their zero value before decoding into them, instead of merging.
+	fatalError        error            // Fatal error occurred during lexing. It is usually a syntax error.
+	multipleErrors    []*LexerError    // Semantic errors occurred during lexing. Marshalling will be continued after finding this errors.
+	loc               locator          // Position of the last located error.
 }
 
 // FetchToken scans the input for the next token.
@@ -242,10 +247,14 @@ func (r *Lexer) fetchNumber() {
 // findStringLen tries to scan into the string literal for ending quote char to determine required size.
 // The size will be exact if no escapes are present and may be inexact if there are escaped chars.
 func findStringLen(data []byte) (isValid bool, length int) {
+	if !fastIndexByte {
+		return findStringLenSWAR(data)
+	}
+
 	for {
 		idx := bytes.IndexByte(data, '"')
 		if idx == -1 {
-			return false, len(data)
+			return false, length + len(data)
 		}
 		if idx == 0 || (idx > 0 && data[idx-1] != '\\') {
 			return true, length + idx
@@ -284,7 +293,7 @@ func (r *Lexer) unescapeStringToken() (err error) {
 		}
 
 		if unescapedData == nil {
-			unescapedData = make([]byte, 0, len(r.token.byteValue))
+			unescapedData = r.alloc(len(r.token.byteValue))
 		}
 
 		var d [4]byte
@@ -412,16 +421,19 @@ const maxErrorContextLen = 13
 func (r *Lexer) errParse(what string) {
 	if r.fatalError == nil {
 		var str string
-		if len(r.Data)-r.pos <= maxErrorContextLen {
-			str = string(r.Data)
+		if r.pos >= len(r.Data) {
+			str = ""
+		} else if len(r.Data)-r.pos <= maxErrorContextLen {
+			str = string(r.Data[r.pos:])
 		} else {
 			str = string(r.Data[r.pos:r.pos+maxErrorContextLen-3]) + "..."
 		}
-		r.fatalError = &LexerError{
+		r.AddError(&LexerError{
 			Reason: what,
+			Err:    ErrSyntax,
 			Offset: r.pos,
 			Data:   str,
-		}
+		})
 	}
 }
 
@@ -447,6 +459,7 @@ func (r *Lexer) errInvalidToken(expected string) {
 		}
 		r.addNonfatalError(&LexerError{
 			Reason: "expected " + expected,
+			Err:    ErrTypeMismatch,
 			Offset: r.start,
 			Data:   string(r.Data[r.start:r.pos]),
 		})
@@ -459,17 +472,23 @@ func (r *Lexer) errInvalidToken(expected string) {
 	} else {
 		str = string(r.token.byteValue[:maxErrorContextLen-3]) + "..."
 	}
-	r.fatalError = &LexerError{
+	r.AddError(&LexerError{
 		Reason: "expected " + expected,
-		Offset: r.pos,
+		Err:    ErrTypeMismatch,
+		Offset: r.start,
 		Data:   str,
-	}
+	})
 }
 
 func (r *Lexer) GetPos() int {
 	return r.pos
 }
 
+// TokenStart returns the offset of the last scanned token, i.e. of the value about to be read.
+func (r *Lexer) TokenStart() int {
+	return r.start
+}
+
 // Delim consumes a token and verifies that it is the given delimiter.
 func (r *Lexer) Delim(c byte) {
 	if r.token.kind == tokenUndef && r.Ok() {
@@ -511,6 +530,30 @@ func (r *Lexer) IsNull() bool {
 	return r.Ok() && r.token.kind == tokenNull
 }
 
+// IsString returns true if the next token is a string literal.
+func (r *Lexer) IsString() bool {
+	if r.token.kind == tokenUndef && r.Ok() {
+		r.FetchToken()
+	}
+	return r.Ok() && r.token.kind == tokenString
+}
+
+// IsNumber returns true if the next token is a number literal.
+func (r *Lexer) IsNumber() bool {
+	if r.token.kind == tokenUndef && r.Ok() {
+		r.FetchToken()
+	}
+	return r.Ok() && r.token.kind == tokenNumber
+}
+
+// IsBool returns true if the next token is a true or false keyword.
+func (r *Lexer) IsBool() bool {
+	if r.token.kind == tokenUndef && r.Ok() {
+		r.FetchToken()
+	}
+	return r.Ok() && r.token.kind == tokenBool
+}
+
 // Skip skips a single token.
 func (r *Lexer) Skip() {
 	if r.token.kind == tokenUndef && r.Ok() {
@@ -554,11 +597,11 @@ func (r *Lexer) SkipRecursive() {
 				r.pos += i + 1
 				if !ValidJSON(r.Data[startPos:r.pos]) {
 					r.pos = len(r.Data)
-					r.fatalError = &LexerError{
+					r.AddError(&LexerError{
 						Reason: "skipped array/object json value is invalid",
-						Offset: r.pos,
-						Data:   string(r.Data[r.pos:]),
-					}
+						Err:    ErrSyntax,
+						Offset: startPos,
+					})
 				}
 				return
 			}
@@ -573,11 +616,11 @@ func (r *Lexer) SkipRecursive() {
 		wasEscape = false
 	}
 	r.pos = len(r.Data)
-	r.fatalError = &LexerError{
+	r.AddError(&LexerError{
 		Reason: "EOF reached while skipping array/object or token",
+		Err:    ErrSyntax,
 		Offset: r.pos,
-		Data:   string(r.Data[r.pos:]),
-	}
+	})
 }
 
 // Raw fetches the next item recursively as a data slice
@@ -606,6 +649,7 @@ func (r *Lexer) Consumed() {
 		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
 			r.AddError(&LexerError{
 				Reason: "invalid character '" + string(c) + "' after top-level value",
+				Err:    ErrTrailingData,
 				Offset: r.pos,
 				Data:   string(r.Data[r.pos:]),
 			})
@@ -659,6 +703,16 @@ func (r *Lexer) UnsafeFieldName(skipUnescape bool) string {
 	return ret
 }
 
+// UnsafeFieldNameBytes returns the name of an object member as a byte slice, so that it can be
+// matched without building a string.
+//
+// Warning: returned slice may point to the input buffer, so it should not outlive the input
+// buffer.
+func (r *Lexer) UnsafeFieldNameBytes(skipUnescape bool) []byte {
+	_, ret := r.unsafeString(skipUnescape)
+	return ret
+}
+
 // String reads a string literal.
 func (r *Lexer) String() string {
 	if r.token.kind == tokenUndef && r.Ok() {
@@ -673,9 +727,12 @@ func (r *Lexer) String() string {
 		return ""
 	}
 	var ret string
-	if r.token.byteValueCloned {
+	switch {
+	case r.token.byteValueCloned:
 		ret = bytesToStr(r.token.byteValue)
-	} else {
+	case r.Alloc != nil:
+		ret = bytesToStr(append(r.alloc(len(r.token.byteValue)), r.token.byteValue...))
+	default:
 		ret = string(r.token.byteValue)
 	}
 	r.consume()
@@ -713,12 +770,16 @@ func (r *Lexer) Bytes() []byte {
 		r.errInvalidToken("string")
 		return nil
 	}
-	ret := make([]byte, base64.StdEncoding.DecodedLen(len(r.token.byteValue)))
+	size := base64.StdEncoding.DecodedLen(len(r.token.byteValue))
+	ret := r.alloc(size)[:size]
 	n, err := base64.StdEncoding.Decode(ret, r.token.byteValue)
 	if err != nil {
-		r.fatalError = &LexerError{
+		r.AddError(&LexerError{
 			Reason: err.Error(),
-		}
+			Err:    ErrInvalidValue,
+			Offset: r.start,
+			Data:   string(r.token.byteValue),
+		})
 		return nil
 	}
 
@@ -726,6 +787,131 @@ func (r *Lexer) Bytes() []byte {
 	return ret[:n]
 }
 
+// Base64Bytes reads a string literal holding base64 data with standard padding and returns the
+// decoded bytes. Unlike Bytes, it only accepts the canonical encoding: line breaks, missing
+// padding and non-zero trailing bits are rejected.
+func (r *Lexer) Base64Bytes() []byte {
+	_, b := r.unsafeString(false)
+	if !r.Ok() || b == nil {
+		return nil
+	}
+	ret, err := decodeBase64Strict(r.alloc(base64.StdEncoding.DecodedLen(len(b))), b)
+	if err != nil {
+		r.addNonfatalError(&LexerError{
+			Offset: r.start,
+			Reason: err.Error(),
+			Err:    ErrInvalidValue,
+			Data:   string(b),
+		})
+		return nil
+	}
+	return ret
+}
+
+// HexBytes reads a string literal holding lowercase hex data and returns the decoded bytes.
+func (r *Lexer) HexBytes() []byte {
+	_, b := r.unsafeString(false)
+	if !r.Ok() || b == nil {
+		return nil
+	}
+	ret, err := decodeHex(r.alloc(len(b)/2), b)
+	if err != nil {
+		r.addNonfatalError(&LexerError{
+			Offset: r.start,
+			Reason: err.Error(),
+			Err:    ErrInvalidValue,
+			Data:   string(b),
+		})
+		return nil
+	}
+	return ret
+}
+
+// Base64Array reads base64 data like Base64Bytes into dst, which the data must fill exactly.
+func (r *Lexer) Base64Array(dst []byte) {
+	r.fixedBytes(dst, r.Base64Bytes)
+}
+
+// HexArray reads hex data like HexBytes into dst, which the data must fill exactly.
+func (r *Lexer) HexArray(dst []byte) {
+	r.fixedBytes(dst, r.HexBytes)
+}
+
+func (r *Lexer) fixedBytes(dst []byte, read func() []byte) {
+	start := r.pos
+	b := read()
+	if !r.Ok() || b == nil && len(dst) > 0 {
+		return
+	}
+	if len(b) != len(dst) {
+		r.addNonfatalError(&LexerError{
+			Offset: r.start,
+			Reason: "expected " + strconv.Itoa(len(dst)) + " bytes, got " + strconv.Itoa(len(b)),
+			Err:    ErrInvalidValue,
+			Data:   string(bytes.TrimSpace(r.Data[start:r.pos])),
+		})
+		return
+	}
+	copy(dst, b)
+}
+
+var (
+	errInvalidBase64 = NewError("invalid base64 data")
+	errInvalidHex    = NewError("invalid hex data")
+)
+
+// alloc returns an empty byte slice with a capacity of at least n bytes, taken from the
+// allocator of the lexer if it has one.
+func (r *Lexer) alloc(n int) []byte {
+	if r.Alloc != nil {
+		return r.Alloc.Get(n)
+	}
+	return make([]byte, 0, n)
+}
+
+// decodeBase64Strict decodes base64 data with standard padding, only accepting the canonical
+// encoding.
+func decodeBase64Strict(dst, data []byte) ([]byte, error) {
+	for _, c := range data {
+		if c == '\r' || c == '\n' {
+			return nil, errInvalidBase64
+		}
+	}
+	ret := dst[:base64.StdEncoding.DecodedLen(len(data))]
+	n, err := base64.StdEncoding.Strict().Decode(ret, data)
+	if err != nil {
+		return nil, errInvalidBase64
+	}
+	return ret[:n], nil
+}
+
+// decodeHex decodes lowercase hex data.
+func decodeHex(dst, data []byte) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, errInvalidHex
+	}
+	ret := dst[:len(data)/2]
+	for i := range ret {
+		hi, ok1 := fromHexChar(data[2*i])
+		lo, ok2 := fromHexChar(data[2*i+1])
+		if !ok1 || !ok2 {
+			return nil, errInvalidHex
+		}
+		ret[i] = hi<<4 | lo
+	}
+	return ret, nil
+}
+
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	}
+	return 0, false
+}
+
 // Bool reads a true or false boolean keyword.
 func (r *Lexer) Bool() bool {
 	if r.token.kind == tokenUndef && r.Ok() {
@@ -753,9 +939,77 @@ func (r *Lexer) number() string {
 	return ret
 }
 
+// Number reads a number literal and returns it as written, so that it can be kept without
+// losing precision. Unlike the readers of Go number types, it checks that the literal follows
+// the JSON grammar.
+func (r *Lexer) Number() string {
+	if r.token.kind == tokenUndef && r.Ok() {
+		r.FetchToken()
+	}
+	if !r.Ok() || r.token.kind != tokenNumber {
+		r.errInvalidToken("number")
+		return ""
+	}
+	if !isValidNumber(r.token.byteValue) {
+		r.AddError(&LexerError{
+			Reason: "invalid number",
+			Err:    ErrSyntax,
+			Offset: r.start,
+			Data:   string(r.token.byteValue),
+		})
+		return ""
+	}
+	ret := string(r.token.byteValue)
+	r.consume()
+	return ret
+}
+
+// isValidNumber reports whether data is a number literal as defined by the JSON grammar: an
+// optional minus sign, an integer without leading zeros, then optional fraction and exponent.
+func isValidNumber(data []byte) bool {
+	i := 0
+	if i < len(data) && data[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(data) && data[i] == '0':
+		i++
+	case i < len(data) && data[i] >= '1' && data[i] <= '9':
+		for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+			i++
+		}
+	default:
+		return false
+	}
+	if i < len(data) && data[i] == '.' {
+		i++
+		start := i
+		for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	if i < len(data) && (data[i] == 'e' || data[i] == 'E') {
+		i++
+		if i < len(data) && (data[i] == '+' || data[i] == '-') {
+			i++
+		}
+		start := i
+		for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	return i == len(data)
+}
+
 func (r *Lexer) Uint8() uint8 {
 	s := r.number()
-	if !r.Ok() {
+	if !r.Ok() || s == "" {
 		return 0
 	}
 
@@ -764,6 +1018,7 @@ func (r *Lexer) Uint8() uint8 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   s,
 		})
 	}
@@ -772,7 +1027,7 @@ func (r *Lexer) Uint8() uint8 {
 
 func (r *Lexer) Uint16() uint16 {
 	s := r.number()
-	if !r.Ok() {
+	if !r.Ok() || s == "" {
 		return 0
 	}
 
@@ -781,6 +1036,7 @@ func (r *Lexer) Uint16() uint16 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   s,
 		})
 	}
@@ -789,7 +1045,7 @@ func (r *Lexer) Uint16() uint16 {
 
 func (r *Lexer) Uint32() uint32 {
 	s := r.number()
-	if !r.Ok() {
+	if !r.Ok() || s == "" {
 		return 0
 	}
 
@@ -798,6 +1054,7 @@ func (r *Lexer) Uint32() uint32 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   s,
 		})
 	}
@@ -806,7 +1063,7 @@ func (r *Lexer) Uint32() uint32 {
 
 func (r *Lexer) Uint64() uint64 {
 	s := r.number()
-	if !r.Ok() {
+	if !r.Ok() || s == "" {
 		return 0
 	}
 
@@ -815,6 +1072,7 @@ func (r *Lexer) Uint64() uint64 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   s,
 		})
 	}
@@ -827,7 +1085,7 @@ func (r *Lexer) Uint() uint {
 
 func (r *Lexer) Int8() int8 {
 	s := r.number()
-	if !r.Ok() {
+	if !r.Ok() || s == "" {
 		return 0
 	}
 
@@ -836,6 +1094,7 @@ func (r *Lexer) Int8() int8 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   s,
 		})
 	}
@@ -844,7 +1103,7 @@ func (r *Lexer) Int8() int8 {
 
 func (r *Lexer) Int16() int16 {
 	s := r.number()
-	if !r.Ok() {
+	if !r.Ok() || s == "" {
 		return 0
 	}
 
@@ -853,6 +1112,7 @@ func (r *Lexer) Int16() int16 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   s,
 		})
 	}
@@ -861,7 +1121,7 @@ func (r *Lexer) Int16() int16 {
 
 func (r *Lexer) Int32() int32 {
 	s := r.number()
-	if !r.Ok() {
+	if !r.Ok() || s == "" {
 		return 0
 	}
 
@@ -870,6 +1130,7 @@ func (r *Lexer) Int32() int32 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   s,
 		})
 	}
@@ -878,7 +1139,7 @@ func (r *Lexer) Int32() int32 {
 
 func (r *Lexer) Int64() int64 {
 	s := r.number()
-	if !r.Ok() {
+	if !r.Ok() || s == "" {
 		return 0
 	}
 
@@ -887,6 +1148,7 @@ func (r *Lexer) Int64() int64 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   s,
 		})
 	}
@@ -899,7 +1161,7 @@ func (r *Lexer) Int() int {
 
 func (r *Lexer) Uint8Str() uint8 {
 	s, b := r.unsafeString(false)
-	if !r.Ok() {
+	if !r.Ok() || b == nil {
 		return 0
 	}
 
@@ -908,6 +1170,7 @@ func (r *Lexer) Uint8Str() uint8 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   string(b),
 		})
 	}
@@ -916,7 +1179,7 @@ func (r *Lexer) Uint8Str() uint8 {
 
 func (r *Lexer) Uint16Str() uint16 {
 	s, b := r.unsafeString(false)
-	if !r.Ok() {
+	if !r.Ok() || b == nil {
 		return 0
 	}
 
@@ -925,6 +1188,7 @@ func (r *Lexer) Uint16Str() uint16 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   string(b),
 		})
 	}
@@ -933,7 +1197,7 @@ func (r *Lexer) Uint16Str() uint16 {
 
 func (r *Lexer) Uint32Str() uint32 {
 	s, b := r.unsafeString(false)
-	if !r.Ok() {
+	if !r.Ok() || b == nil {
 		return 0
 	}
 
@@ -942,6 +1206,7 @@ func (r *Lexer) Uint32Str() uint32 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   string(b),
 		})
 	}
@@ -950,7 +1215,7 @@ func (r *Lexer) Uint32Str() uint32 {
 
 func (r *Lexer) Uint64Str() uint64 {
 	s, b := r.unsafeString(false)
-	if !r.Ok() {
+	if !r.Ok() || b == nil {
 		return 0
 	}
 
@@ -959,6 +1224,7 @@ func (r *Lexer) Uint64Str() uint64 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   string(b),
 		})
 	}
@@ -975,7 +1241,7 @@ func (r *Lexer) UintptrStr() uintptr {
 
 func (r *Lexer) Int8Str() int8 {
 	s, b := r.unsafeString(false)
-	if !r.Ok() {
+	if !r.Ok() || b == nil {
 		return 0
 	}
 
@@ -984,6 +1250,7 @@ func (r *Lexer) Int8Str() int8 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   string(b),
 		})
 	}
@@ -992,7 +1259,7 @@ func (r *Lexer) Int8Str() int8 {
 
 func (r *Lexer) Int16Str() int16 {
 	s, b := r.unsafeString(false)
-	if !r.Ok() {
+	if !r.Ok() || b == nil {
 		return 0
 	}
 
@@ -1001,6 +1268,7 @@ func (r *Lexer) Int16Str() int16 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   string(b),
 		})
 	}
@@ -1009,7 +1277,7 @@ func (r *Lexer) Int16Str() int16 {
 
 func (r *Lexer) Int32Str() int32 {
 	s, b := r.unsafeString(false)
-	if !r.Ok() {
+	if !r.Ok() || b == nil {
 		return 0
 	}
 
@@ -1018,6 +1286,7 @@ func (r *Lexer) Int32Str() int32 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   string(b),
 		})
 	}
@@ -1026,7 +1295,7 @@ func (r *Lexer) Int32Str() int32 {
 
 func (r *Lexer) Int64Str() int64 {
 	s, b := r.unsafeString(false)
-	if !r.Ok() {
+	if !r.Ok() || b == nil {
 		return 0
 	}
 
@@ -1035,6 +1304,7 @@ func (r *Lexer) Int64Str() int64 {
 		r.addNonfatalError(&LexerError{
 			Offset: r.start,
 			Reason: err.Error(),
+			Err:    numberError(err),
 			Data:   string(b),
 		})
 	}
@@ -1051,34 +1321,74 @@ func (r *Lexer) Error() error {
 
 func (r *Lexer) AddError(e error) {
 	if r.fatalError == nil {
+		if lerr, ok := e.(*LexerError); ok {
+			r.locate(lerr)
+		}
 		r.fatalError = e
 	}
 }
 
+// AddNonFatalError records an error that does not prevent decoding the rest of the input, such
+// as a value of the wrong type or a failing custom unmarshaler. In multiple errors mode decoding
+// continues, otherwise the error becomes the fatal one. Errors other than *LexerError are
+// wrapped into one spanning the current token. Nil errors are ignored.
 func (r *Lexer) AddNonFatalError(e error) {
-	r.addNonfatalError(&LexerError{
-		Offset: r.start,
-		Data:   string(r.Data[r.start:r.pos]),
-		Reason: e.Error(),
-	})
+	if e == nil {
+		return
+	}
+	lerr, ok := e.(*LexerError)
+	if !ok {
+		lerr = &LexerError{
+			Offset: r.start,
+			Data:   string(r.Data[r.start:r.pos]),
+			Reason: e.Error(),
+			Err:    e,
+		}
+	}
+	r.addNonfatalError(lerr)
 }
 
 func (r *Lexer) addNonfatalError(err *LexerError) {
 	if r.UseMultipleErrors {
-		// We don't want to add errors with the same offset.
-		if len(r.multipleErrors) != 0 && r.multipleErrors[len(r.multipleErrors)-1].Offset == err.Offset {
-			return
+		// We don't want to add the same error twice.
+		for _, e := range r.multipleErrors {
+			if e.Offset == err.Offset && e.Reason == err.Reason {
+				return
+			}
 		}
 		r.multipleErrors = append(r.multipleErrors, err)
 		return
 	}
-	r.fatalError = err
+	if r.fatalError == nil {
+		r.locate(err)
+		r.fatalError = err
+	}
 }
 
 func (r *Lexer) GetNonFatalErrors() []*LexerError {
+	r.locate(r.multipleErrors...)
 	return r.multipleErrors
 }
 
+// Errors returns all errors met during decoding, the non-fatal ones collected in multiple errors
+// mode followed by the fatal one, as a *MultiError. It returns nil if there were no errors.
+func (r *Lexer) Errors() error {
+	var errs []*LexerError
+	errs = append(errs, r.multipleErrors...)
+	switch err := r.fatalError.(type) {
+	case nil:
+	case *LexerError:
+		errs = append(errs, err)
+	default:
+		errs = append(errs, &LexerError{Offset: r.pos, Reason: err.Error(), Err: err})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	r.locate(errs...)
+	return &MultiError{Errors: errs}
+}
+
 // Interface fetches an interface{} analogous to the 'encoding/json' package.
 func (r *Lexer) Interface() interface{} {
 	if r.token.kind == tokenUndef && r.Ok() {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+
+	"github.com/CosmWasm/tinyjson/buffer"
 )
 
 func TestString(t *testing.T) {
@@ -144,6 +146,39 @@ func TestNumber(t *testing.T) {
 	}
 }
 
+func TestNumberLiteral(t *testing.T) {
+	for i, test := range []struct {
+		toParse   string
+		want      string
+		wantError bool
+	}{
+		{toParse: "0", want: "0"},
+		{toParse: "-0.5", want: "-0.5"},
+		{toParse: "18446744073709551616", want: "18446744073709551616"},
+		{toParse: "1E+2", want: "1E+2"},
+
+		{toParse: "01", wantError: true},
+		{toParse: "1.", wantError: true},
+		{toParse: "-", wantError: true},
+		{toParse: "1e", wantError: true},
+		{toParse: "1e+", wantError: true},
+		{toParse: "true", wantError: true},
+	} {
+		l := Lexer{Data: []byte(test.toParse)}
+
+		got := l.Number()
+		if got != test.want {
+			t.Errorf("[%d, %q] Number() = %v; want %v", i, test.toParse, got, test.want)
+		}
+		err := l.Error()
+		if err != nil && !test.wantError {
+			t.Errorf("[%d, %q] Number() error: %v", i, test.toParse, err)
+		} else if err == nil && test.wantError {
+			t.Errorf("[%d, %q] Number() ok; want error", i, test.toParse)
+		}
+	}
+}
+
 func TestBool(t *testing.T) {
 	for i, test := range []struct {
 		toParse   string
@@ -310,3 +345,92 @@ func TestFetchStringUnterminatedString(t *testing.T) {
 		}
 	}
 }
+
+func TestBase64Bytes(t *testing.T) {
+	for i, test := range []struct {
+		toParse   string
+		want      string
+		wantError bool
+	}{
+		{toParse: `""`, want: ""},
+		{toParse: `"aGVsbG8="`, want: "hello"},
+		{toParse: `"aGVsbG8h"`, want: "hello!"},
+
+		{toParse: `"aGVsbG8"`, wantError: true},
+		{toParse: `"aGVsbG9="`, wantError: true},
+		{toParse: `"aGVs\nbG8="`, wantError: true},
+		{toParse: `"aGVsbG8_"`, wantError: true},
+		{toParse: `5`, wantError: true},
+	} {
+		l := Lexer{Data: []byte(test.toParse)}
+
+		got := l.Base64Bytes()
+		if string(got) != test.want {
+			t.Errorf("[%d, %q] Base64Bytes() = %q; want %q", i, test.toParse, got, test.want)
+		}
+		err := l.Error()
+		if err != nil && !test.wantError {
+			t.Errorf("[%d, %q] Base64Bytes() error: %v", i, test.toParse, err)
+		} else if err == nil && test.wantError {
+			t.Errorf("[%d, %q] Base64Bytes() ok; want error", i, test.toParse)
+		}
+	}
+}
+
+func TestHexArray(t *testing.T) {
+	for i, test := range []struct {
+		toParse   string
+		want      [2]byte
+		wantError bool
+	}{
+		{toParse: `"00ff"`, want: [2]byte{0x00, 0xff}},
+		{toParse: `"beef"`, want: [2]byte{0xbe, 0xef}},
+
+		{toParse: `"BEEF"`, wantError: true},
+		{toParse: `"bee"`, wantError: true},
+		{toParse: `"be"`, wantError: true},
+		{toParse: `"beef00"`, wantError: true},
+		{toParse: `"0xbe"`, wantError: true},
+	} {
+		l := Lexer{Data: []byte(test.toParse)}
+
+		var got [2]byte
+		l.HexArray(got[:])
+		if got != test.want {
+			t.Errorf("[%d, %q] HexArray() = %x; want %x", i, test.toParse, got, test.want)
+		}
+		err := l.Error()
+		if err != nil && !test.wantError {
+			t.Errorf("[%d, %q] HexArray() error: %v", i, test.toParse, err)
+		} else if err == nil && test.wantError {
+			t.Errorf("[%d, %q] HexArray() ok; want error", i, test.toParse)
+		}
+	}
+}
+
+func TestAlloc(t *testing.T) {
+	arena := buffer.NewArena(64)
+	l := Lexer{Data: []byte(`["hello", "a\u0062c", "aGk=", "dead"]`), Alloc: arena}
+
+	l.Delim('[')
+	s1 := l.String()
+	l.WantComma()
+	s2 := l.String()
+	l.WantComma()
+	b1 := l.Base64Bytes()
+	l.WantComma()
+	b2 := l.HexBytes()
+	l.WantComma()
+	l.Delim(']')
+	if err := l.Error(); err != nil {
+		t.Fatalf("Error() = %v", err)
+	}
+
+	if s1 != "hello" || s2 != "abc" || string(b1) != "hi" || string(b2) != "\xde\xad" {
+		t.Errorf("got %q, %q, %q, %q; want hello, abc, hi, \\xde\\xad", s1, s2, b1, b2)
+	}
+	// "hello", the escaped "a\u0062c", the base64 decoding buffer and the hex data.
+	if got, want := arena.Used(), 5+8+3+2; got != want {
+		t.Errorf("arena.Used() = %d; want %d", got, want)
+	}
+}
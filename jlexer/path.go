@@ -0,0 +1,127 @@
+package jlexer
+
+import "strconv"
+
+// pathFrame is an object or array being scanned by a pathScanner.
+type pathFrame struct {
+	object  bool
+	key     []byte
+	index   int
+	inValue bool // Whether the scan is in or right after the value of key or index.
+}
+
+// PathAt returns the JSON path, e.g. $.funds[1].amount, of the value at offset in data: the
+// value starting at or containing offset or, if offset is between members, the last value
+// before it. It works on a best-effort basis for malformed input.
+func PathAt(data []byte, offset int) string {
+	var s pathScanner
+	return s.pathAt(data, offset)
+}
+
+// pathScanner keeps the state of a scan for PathAt, so that the paths of increasing offsets can
+// be found in a single pass over the data.
+type pathScanner struct {
+	pos   int // Next byte to scan, after offset if it is in a string.
+	stack []pathFrame
+}
+
+// pathAt returns the path of the value at offset, resuming the scan where it stopped. offset
+// must not be smaller than the one of the previous call.
+func (s *pathScanner) pathAt(data []byte, offset int) string {
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	stack := s.stack
+	i := s.pos
+	for ; i < offset; i++ {
+		c := data[i]
+		if n := len(stack); n > 0 && !stack[n-1].object && !stack[n-1].inValue {
+			switch c {
+			case ' ', '\t', '\r', '\n', ',', ']':
+			default:
+				stack[n-1].inValue = true
+			}
+		}
+		switch c {
+		case '{', '[':
+			stack = append(stack, pathFrame{object: c == '{'})
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case ',':
+			if n := len(stack); n > 0 {
+				top := &stack[n-1]
+				if top.object {
+					top.inValue = false
+				} else {
+					top.index++
+					top.inValue = false
+				}
+			}
+		case ':':
+			if n := len(stack); n > 0 {
+				stack[n-1].inValue = true
+			}
+		case '"':
+			start := i + 1
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			if n := len(stack); n > 0 && stack[n-1].object && !stack[n-1].inValue && i <= len(data) {
+				end := i
+				if end > len(data) {
+					end = len(data)
+				}
+				stack[n-1].key = data[start:end]
+			}
+		}
+	}
+	s.pos, s.stack = i, stack
+
+	// An array element starting right at offset is the one the path is about.
+	top := len(stack) - 1
+	elem := false
+	if top >= 0 && !stack[top].object && !stack[top].inValue && offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ']':
+		default:
+			elem = true
+		}
+	}
+
+	path := []byte("$")
+	for j, f := range stack {
+		if !f.inValue && !(elem && j == top) {
+			break
+		}
+		if !f.object {
+			path = append(path, '[')
+			path = strconv.AppendInt(path, int64(f.index), 10)
+			path = append(path, ']')
+		} else if isPathIdent(f.key) {
+			path = append(path, '.')
+			path = append(path, f.key...)
+		} else {
+			path = append(path, '[')
+			path = strconv.AppendQuote(path, string(f.key))
+			path = append(path, ']')
+		}
+	}
+	return string(path)
+}
+
+func isPathIdent(key []byte) bool {
+	if len(key) == 0 {
+		return false
+	}
+	for _, c := range key {
+		if !(c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
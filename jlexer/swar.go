@@ -0,0 +1,53 @@
+package jlexer
+
+import "github.com/CosmWasm/tinyjson/internal/swar"
+
+// Word-at-a-time (SWAR) helpers checking 8 bytes of input per step, portable and without unsafe.
+
+const (
+	swarQuotes      = '"' * swar.Ones
+	swarBackslashes = '\\' * swar.Ones
+)
+
+// load64 returns 8 bytes of b as a little-endian word. The compiler turns it into a single load
+// where the platform allows it.
+func load64(b []byte) uint64 {
+	_ = b[7]
+	return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+}
+
+// hasQuoteOrBackslash reports whether any byte of x is a quote or a backslash.
+func hasQuoteOrBackslash(x uint64) bool {
+	return swar.ZeroBytes(x^swarQuotes)|swar.ZeroBytes(x^swarBackslashes) != 0
+}
+
+// findStringLenSWAR implements findStringLen checking 8 bytes per step for quotes and
+// backslashes, for platforms without a vectorized bytes.IndexByte.
+func findStringLenSWAR(data []byte) (isValid bool, length int) {
+	i := 0
+	for i < len(data) {
+		if i+8 <= len(data) && !hasQuoteOrBackslash(load64(data[i:])) {
+			i += 8
+			continue
+		}
+
+		// Check the bytes of the word one at a time.
+		end := i + 8
+		if end > len(data) {
+			end = len(data)
+		}
+		for i < end {
+			switch data[i] {
+			case '"':
+				return true, i
+			case '\\':
+				// Skip the escaped character, which may be a quote.
+				i += 2
+			default:
+				i++
+			}
+		}
+	}
+	return false, len(data)
+}
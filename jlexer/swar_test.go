@@ -0,0 +1,84 @@
+package jlexer
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// findStringLenBytes is the byte at a time reference implementation of findStringLen.
+func findStringLenBytes(data []byte) (bool, int) {
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			return true, i
+		case '\\':
+			i++
+		}
+	}
+	return false, len(data)
+}
+
+func TestFindStringLen(t *testing.T) {
+	for _, test := range []string{
+		``,
+		`"`,
+		`abc"`,
+		`abcdefghijklmnop`,
+		`abcdefghijklmnop"`,
+		`abcdefg\"hijklmnop"`,
+		`abcdefg\\"hijklmnop"`,
+		`abcdefg\\\"hijklmnop"`,
+		`abcdefgh\`,
+		`abcdefgh\"`,
+		`\\\\\\\\\\\\\\\\"`,
+	} {
+		wantOk, wantLen := findStringLenBytes([]byte(test))
+		if ok, n := findStringLen([]byte(test)); ok != wantOk || n != wantLen {
+			t.Errorf("findStringLen(%q) = %v, %d; want %v, %d", test, ok, n, wantOk, wantLen)
+		}
+		if ok, n := findStringLenSWAR([]byte(test)); ok != wantOk || n != wantLen {
+			t.Errorf("findStringLenSWAR(%q) = %v, %d; want %v, %d", test, ok, n, wantOk, wantLen)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	alphabet := []byte(`ab"\\`)
+	for i := 0; i < 10000; i++ {
+		data := make([]byte, rnd.Intn(40))
+		for j := range data {
+			if rnd.Intn(4) == 0 {
+				data[j] = alphabet[rnd.Intn(len(alphabet))]
+			} else {
+				data[j] = byte('a' + rnd.Intn(26))
+			}
+		}
+		wantOk, wantLen := findStringLenBytes(data)
+		if ok, n := findStringLenSWAR(data); ok != wantOk || n != wantLen {
+			t.Fatalf("findStringLenSWAR(%q) = %v, %d; want %v, %d", data, ok, n, wantOk, wantLen)
+		}
+	}
+}
+
+var benchStringData = []byte(strings.Repeat("cosmwasm contract message ", 40) + `"`)
+
+func BenchmarkFindStringLen(b *testing.B) {
+	b.SetBytes(int64(len(benchStringData)))
+	for i := 0; i < b.N; i++ {
+		findStringLen(benchStringData)
+	}
+}
+
+func BenchmarkFindStringLenSWAR(b *testing.B) {
+	b.SetBytes(int64(len(benchStringData)))
+	for i := 0; i < b.N; i++ {
+		findStringLenSWAR(benchStringData)
+	}
+}
+
+func BenchmarkFindStringLenBytes(b *testing.B) {
+	b.SetBytes(int64(len(benchStringData)))
+	for i := 0; i < b.N; i++ {
+		findStringLenBytes(benchStringData)
+	}
+}
@@ -0,0 +1,206 @@
+package jlexer
+
+import "github.com/CosmWasm/tinyjson/internal/prototime"
+
+// This file implements RFC 3339 and duration parsing without the time package, which relies
+// on reflection-heavy formatting paths not available under TinyGo.
+
+var (
+	errInvalidTimestamp = NewError("invalid RFC 3339 timestamp")
+	errInvalidDuration  = NewError("invalid duration")
+	errTimestampRange   = NewError("timestamp out of range")
+	errDurationRange    = NewError("duration out of range")
+)
+
+// daysFromCivil converts a proleptic Gregorian calendar date to days since 1970-01-01.
+func daysFromCivil(year, month, day int64) int64 {
+	if month <= 2 {
+		year--
+	}
+	era := year / 400
+	if year < 0 && year%400 != 0 {
+		era--
+	}
+	yoe := year - era*400
+	mp := month - 3
+	if month <= 2 {
+		mp = month + 9
+	}
+	doy := (153*mp+2)/5 + day - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+func daysInMonth(year, month int64) int64 {
+	switch month {
+	case 2:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
+
+// parseDigits parses exactly n decimal digits from the beginning of s.
+func parseDigits(s []byte, n int) (int64, bool) {
+	if len(s) < n {
+		return 0, false
+	}
+	var v int64
+	for _, c := range s[:n] {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		v = v*10 + int64(c-'0')
+	}
+	return v, true
+}
+
+// parseFraction parses an optional '.' followed by 1 to 9 digits and returns the value in
+// nanoseconds together with the number of bytes consumed.
+func parseFraction(s []byte) (nanos int64, n int, ok bool) {
+	if len(s) == 0 || s[0] != '.' {
+		return 0, 0, true
+	}
+	n = 1
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		if n > 9 {
+			return 0, 0, false
+		}
+		nanos = nanos*10 + int64(s[n]-'0')
+		n++
+	}
+	if n == 1 {
+		return 0, 0, false
+	}
+	for i := n; i <= 9; i++ {
+		nanos *= 10
+	}
+	return nanos, n, true
+}
+
+// ParseRFC3339 parses an RFC 3339 timestamp such as 2021-08-22T10:00:20.021Z or
+// 2021-08-22T12:00:20+02:00 into seconds and nanoseconds since the Unix epoch. Like protojson, it
+// rejects timestamps before 0001-01-01T00:00:00Z or after 9999-12-31T23:59:59.999999999Z.
+func ParseRFC3339(s []byte) (seconds int64, nanos int32, err error) {
+	if len(s) < 20 || s[4] != '-' || s[7] != '-' || (s[10] != 'T' && s[10] != 't') || s[13] != ':' || s[16] != ':' {
+		return 0, 0, errInvalidTimestamp
+	}
+	year, ok1 := parseDigits(s, 4)
+	month, ok2 := parseDigits(s[5:], 2)
+	day, ok3 := parseDigits(s[8:], 2)
+	hour, ok4 := parseDigits(s[11:], 2)
+	min, ok5 := parseDigits(s[14:], 2)
+	sec, ok6 := parseDigits(s[17:], 2)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || !ok6 ||
+		month < 1 || month > 12 || day < 1 || day > daysInMonth(year, month) ||
+		hour > 23 || min > 59 || sec > 59 {
+		return 0, 0, errInvalidTimestamp
+	}
+
+	frac, n, ok := parseFraction(s[19:])
+	if !ok {
+		return 0, 0, errInvalidTimestamp
+	}
+	zone := s[19+n:]
+
+	var offset int64
+	switch {
+	case len(zone) == 1 && (zone[0] == 'Z' || zone[0] == 'z'):
+	case len(zone) == 6 && (zone[0] == '+' || zone[0] == '-') && zone[3] == ':':
+		zh, ok1 := parseDigits(zone[1:], 2)
+		zm, ok2 := parseDigits(zone[4:], 2)
+		if !ok1 || !ok2 || zh > 23 || zm > 59 {
+			return 0, 0, errInvalidTimestamp
+		}
+		offset = zh*3600 + zm*60
+		if zone[0] == '-' {
+			offset = -offset
+		}
+	default:
+		return 0, 0, errInvalidTimestamp
+	}
+
+	seconds = daysFromCivil(year, month, day)*86400 + hour*3600 + min*60 + sec - offset
+	if !prototime.ValidTimestamp(seconds, int32(frac)) {
+		return 0, 0, errTimestampRange
+	}
+	return seconds, int32(frac), nil
+}
+
+// ParseDuration parses a duration in the protobuf JSON format, e.g. 1.000340012s or -3s, into
+// seconds and nanoseconds. Like protojson, it rejects durations of more than 315576000000
+// seconds, about 10,000 years, either way.
+func ParseDuration(s []byte) (seconds int64, nanos int32, err error) {
+	neg := len(s) > 0 && s[0] == '-'
+	if neg {
+		s = s[1:]
+	}
+	if len(s) < 2 || s[len(s)-1] != 's' {
+		return 0, 0, errInvalidDuration
+	}
+	s = s[:len(s)-1]
+
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		if seconds > (1<<63-1)/10-1 {
+			return 0, 0, errInvalidDuration
+		}
+		seconds = seconds*10 + int64(s[i]-'0')
+		i++
+	}
+	if i == 0 {
+		return 0, 0, errInvalidDuration
+	}
+	frac, n, ok := parseFraction(s[i:])
+	if !ok || i+n != len(s) {
+		return 0, 0, errInvalidDuration
+	}
+	if seconds > prototime.MaxDuration {
+		return 0, 0, errDurationRange
+	}
+	if neg {
+		return -seconds, -int32(frac), nil
+	}
+	return seconds, int32(frac), nil
+}
+
+// ProtoTimestamp reads a google.protobuf.Timestamp value encoded as an RFC 3339 string.
+func (r *Lexer) ProtoTimestamp() (seconds int64, nanos int32) {
+	_, b := r.unsafeString(false)
+	if !r.Ok() || b == nil {
+		return 0, 0
+	}
+	seconds, nanos, err := ParseRFC3339(b)
+	if err != nil {
+		r.addNonfatalError(&LexerError{
+			Offset: r.start,
+			Reason: err.Error(),
+			Err:    ErrInvalidValue,
+			Data:   string(b),
+		})
+	}
+	return seconds, nanos
+}
+
+// ProtoDuration reads a google.protobuf.Duration value encoded as a string of seconds with an
+// 's' suffix.
+func (r *Lexer) ProtoDuration() (seconds int64, nanos int32) {
+	_, b := r.unsafeString(false)
+	if !r.Ok() || b == nil {
+		return 0, 0
+	}
+	seconds, nanos, err := ParseDuration(b)
+	if err != nil {
+		r.addNonfatalError(&LexerError{
+			Offset: r.start,
+			Reason: err.Error(),
+			Err:    ErrInvalidValue,
+			Data:   string(b),
+		})
+	}
+	return seconds, nanos
+}
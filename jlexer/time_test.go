@@ -0,0 +1,72 @@
+package jlexer
+
+import (
+	"testing"
+)
+
+func TestParseRFC3339(t *testing.T) {
+	for i, test := range []struct {
+		toParse     string
+		wantSeconds int64
+		wantNanos   int32
+		wantError   bool
+	}{
+		{toParse: "1970-01-01T00:00:00Z"},
+		{toParse: "2023-11-14T22:13:20Z", wantSeconds: 1700000000},
+		{toParse: "2023-11-14T22:13:20.000000001Z", wantSeconds: 1700000000, wantNanos: 1},
+		{toParse: "2023-11-15T00:13:20.5+02:00", wantSeconds: 1700000000, wantNanos: 500000000},
+		{toParse: "1969-12-31T23:59:59Z", wantSeconds: -1},
+		{toParse: "2000-02-29T00:00:00Z", wantSeconds: 951782400},
+
+		{toParse: "2001-02-29T00:00:00Z", wantError: true},
+		{toParse: "2023-11-14T22:13:20", wantError: true},
+		{toParse: "2023-11-14 22:13:20Z", wantError: true},
+		{toParse: "2023-11-14T22:13:20.Z", wantError: true},
+		{toParse: "0001-01-01T00:00:00Z", wantSeconds: -62135596800},
+		{toParse: "9999-12-31T23:59:59.999999999Z", wantSeconds: 253402300799, wantNanos: 999999999},
+		{toParse: "0000-12-31T23:59:59Z", wantError: true},
+		{toParse: "0001-01-01T00:00:00+01:00", wantError: true},
+		{toParse: "9999-12-31T23:59:59-01:00", wantError: true},
+	} {
+		seconds, nanos, err := ParseRFC3339([]byte(test.toParse))
+		if err != nil && !test.wantError {
+			t.Errorf("[%d, %q] ParseRFC3339() error: %v", i, test.toParse, err)
+		} else if err == nil && test.wantError {
+			t.Errorf("[%d, %q] ParseRFC3339() ok; want error", i, test.toParse)
+		} else if seconds != test.wantSeconds || nanos != test.wantNanos {
+			t.Errorf("[%d, %q] ParseRFC3339() = %v, %v; want %v, %v", i, test.toParse, seconds, nanos, test.wantSeconds, test.wantNanos)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for i, test := range []struct {
+		toParse     string
+		wantSeconds int64
+		wantNanos   int32
+		wantError   bool
+	}{
+		{toParse: "0s"},
+		{toParse: "3s", wantSeconds: 3},
+		{toParse: "-3s", wantSeconds: -3},
+		{toParse: "1.000340012s", wantSeconds: 1, wantNanos: 340012},
+		{toParse: "-0.5s", wantNanos: -500000000},
+
+		{toParse: "3", wantError: true},
+		{toParse: "s", wantError: true},
+		{toParse: "1.0000000001s", wantError: true},
+		{toParse: "315576000000.999999999s", wantSeconds: 315576000000, wantNanos: 999999999},
+		{toParse: "-315576000000s", wantSeconds: -315576000000},
+		{toParse: "315576000001s", wantError: true},
+		{toParse: "-315576000001s", wantError: true},
+	} {
+		seconds, nanos, err := ParseDuration([]byte(test.toParse))
+		if err != nil && !test.wantError {
+			t.Errorf("[%d, %q] ParseDuration() error: %v", i, test.toParse, err)
+		} else if err == nil && test.wantError {
+			t.Errorf("[%d, %q] ParseDuration() ok; want error", i, test.toParse)
+		} else if seconds != test.wantSeconds || nanos != test.wantNanos {
+			t.Errorf("[%d, %q] ParseDuration() = %v, %v; want %v, %v", i, test.toParse, seconds, nanos, test.wantSeconds, test.wantNanos)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package jwriter
+
+import "github.com/CosmWasm/tinyjson/internal/swar"
+
+// Word-at-a-time (SWAR) helpers checking 8 bytes of a string per step, portable and without
+// unsafe.
+
+// load64 returns 8 bytes of s as a little-endian word. The compiler turns it into a single load
+// where the platform allows it.
+func load64(s string) uint64 {
+	_ = s[7]
+	return uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24 |
+		uint64(s[4])<<32 | uint64(s[5])<<40 | uint64(s[6])<<48 | uint64(s[7])<<56
+}
+
+// needsEscape reports whether any byte of x may need escaping: control characters, quotes,
+// backslashes, non-ASCII bytes, which are validated, and HTML-sensitive bytes if html is set.
+func needsEscape(x uint64, html bool) bool {
+	// Bytes below 0x20 or with the high bit set.
+	m := (x - 0x20*swar.Ones | x) & swar.Highs
+	m |= swar.ZeroBytes(x^'"'*swar.Ones) | swar.ZeroBytes(x^'\\'*swar.Ones)
+	if html {
+		m |= swar.ZeroBytes(x^'<'*swar.Ones) | swar.ZeroBytes(x^'>'*swar.Ones) | swar.ZeroBytes(x^'&'*swar.Ones)
+	}
+	return m != 0
+}
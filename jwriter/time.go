@@ -0,0 +1,148 @@
+package jwriter
+
+import (
+	"errors"
+
+	"github.com/CosmWasm/tinyjson/internal/prototime"
+)
+
+// This file implements RFC 3339 and duration rendering without the time package, which relies
+// on reflection-heavy formatting paths not available under TinyGo.
+
+var (
+	// ErrTimestampRange is the error of writers given a google.protobuf.Timestamp before
+	// 0001-01-01T00:00:00Z, after 9999-12-31T23:59:59.999999999Z or with nanoseconds out of
+	// [0, 999999999], which RFC 3339 cannot represent.
+	ErrTimestampRange = errors.New("jwriter: timestamp out of range")
+	// ErrDurationRange is the error of writers given a google.protobuf.Duration of more than
+	// 315576000000 seconds either way, or with nanoseconds out of range or of the wrong sign.
+	ErrDurationRange = errors.New("jwriter: duration out of range")
+)
+
+// floorDiv returns a/b rounded towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// civilFromDays converts days since 1970-01-01 to a proleptic Gregorian calendar date.
+func civilFromDays(days int64) (year, month, day int64) {
+	z := days + 719468
+	era := floorDiv(z, 146097)
+	doe := z - era*146097
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+
+	year = yoe + era*400
+	day = doy - (153*mp+2)/5 + 1
+	if mp < 10 {
+		month = mp + 3
+	} else {
+		month = mp - 9
+	}
+	if month <= 2 {
+		year++
+	}
+	return year, month, day
+}
+
+func appendDigits(dst []byte, v int64, width int) []byte {
+	var buf [20]byte
+	i := len(buf)
+	for v > 0 || width > 0 {
+		i--
+		buf[i] = byte('0' + v%10)
+		v /= 10
+		width--
+	}
+	return append(dst, buf[i:]...)
+}
+
+// appendFraction appends nanoseconds as a fraction with 0, 3, 6 or 9 digits, as done by the
+// protobuf JSON mapping.
+func appendFraction(dst []byte, nanos int64) []byte {
+	switch {
+	case nanos == 0:
+		return dst
+	case nanos%1000000 == 0:
+		return appendDigits(append(dst, '.'), nanos/1000000, 3)
+	case nanos%1000 == 0:
+		return appendDigits(append(dst, '.'), nanos/1000, 6)
+	default:
+		return appendDigits(append(dst, '.'), nanos, 9)
+	}
+}
+
+// AppendRFC3339 appends the UTC time given as seconds and nanoseconds since the Unix epoch in
+// RFC 3339 format, e.g. 2021-08-22T10:00:20.021Z. The time must be in the range of years 1 to
+// 9999, with nanoseconds in [0, 999999999].
+func AppendRFC3339(dst []byte, seconds int64, nanos int32) []byte {
+	days := floorDiv(seconds, 86400)
+	secs := seconds - days*86400
+	year, month, day := civilFromDays(days)
+
+	dst = appendDigits(dst, year, 4)
+	dst = append(dst, '-')
+	dst = appendDigits(dst, month, 2)
+	dst = append(dst, '-')
+	dst = appendDigits(dst, day, 2)
+	dst = append(dst, 'T')
+	dst = appendDigits(dst, secs/3600, 2)
+	dst = append(dst, ':')
+	dst = appendDigits(dst, secs/60%60, 2)
+	dst = append(dst, ':')
+	dst = appendDigits(dst, secs%60, 2)
+	dst = appendFraction(dst, int64(nanos))
+	return append(dst, 'Z')
+}
+
+// AppendDuration appends the duration given as seconds and nanoseconds in the protobuf JSON
+// format, e.g. 1.000340012s.
+func AppendDuration(dst []byte, seconds int64, nanos int32) []byte {
+	s, n := seconds, int64(nanos)
+	if s < 0 || n < 0 {
+		dst = append(dst, '-')
+		s, n = -s, -n
+	}
+	if s == 0 {
+		dst = append(dst, '0')
+	} else {
+		dst = appendDigits(dst, s, 0)
+	}
+	dst = appendFraction(dst, n)
+	return append(dst, 's')
+}
+
+// ProtoTimestamp writes a google.protobuf.Timestamp value as an RFC 3339 string, or sets the
+// error of the writer to ErrTimestampRange if it is out of range.
+func (w *Writer) ProtoTimestamp(seconds int64, nanos int32) {
+	if !prototime.ValidTimestamp(seconds, nanos) {
+		if w.Error == nil {
+			w.Error = ErrTimestampRange
+		}
+		return
+	}
+	w.Buffer.EnsureSpace(32)
+	w.Buffer.Buf = append(w.Buffer.Buf, '"')
+	w.Buffer.Buf = AppendRFC3339(w.Buffer.Buf, seconds, nanos)
+	w.Buffer.Buf = append(w.Buffer.Buf, '"')
+}
+
+// ProtoDuration writes a google.protobuf.Duration value as a string of seconds with an 's'
+// suffix, or sets the error of the writer to ErrDurationRange if it is out of range.
+func (w *Writer) ProtoDuration(seconds int64, nanos int32) {
+	if !prototime.ValidDuration(seconds, nanos) {
+		if w.Error == nil {
+			w.Error = ErrDurationRange
+		}
+		return
+	}
+	w.Buffer.EnsureSpace(34)
+	w.Buffer.Buf = append(w.Buffer.Buf, '"')
+	w.Buffer.Buf = AppendDuration(w.Buffer.Buf, seconds, nanos)
+	w.Buffer.Buf = append(w.Buffer.Buf, '"')
+}
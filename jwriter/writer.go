@@ -2,8 +2,10 @@
 package jwriter
 
 import (
+	"errors"
 	"io"
 	"strconv"
+	"unicode/utf16"
 	"unicode/utf8"
 
 	"github.com/CosmWasm/tinyjson/buffer"
@@ -18,13 +20,48 @@ const (
 	NilSliceAsEmpty                   // Encode nil slice as '[]' rather than 'null'.
 )
 
-// Writer is a JSON writer.
+// InvalidUTF8Policy is the way strings with invalid UTF-8 are written.
+type InvalidUTF8Policy uint8
+
+const (
+	// ReplaceInvalidUTF8 writes each invalid byte as the escaped replacement character \ufffd,
+	// like encoding/json.
+	ReplaceInvalidUTF8 InvalidUTF8Policy = iota
+	// ErrorOnInvalidUTF8 sets the error of the writer to ErrInvalidUTF8.
+	ErrorOnInvalidUTF8
+)
+
+// ErrInvalidUTF8 is the error of writers with the ErrorOnInvalidUTF8 policy given invalid UTF-8.
+var ErrInvalidUTF8 = errors.New("jwriter: invalid UTF-8 in string")
+
+// Writer is a JSON writer. The chunks of its buffer come from Buffer.Alloc, if set, e.g. an
+// arena reset after each contract call.
 type Writer struct {
 	Flags Flags
 
 	Error        error
 	Buffer       buffer.Buffer
 	NoEscapeHTML bool
+
+	// ASCIIOnly makes strings be written in ASCII, escaping other characters as \uXXXX, and as
+	// UTF-16 surrogate pairs above U+FFFF. It also applies to the JSON given to Raw, e.g. by
+	// json.Marshaler implementations or RawMessage, where such characters can only be in strings.
+	ASCIIOnly bool
+
+	// NoEscapeLineSeparators makes U+2028 and U+2029 be written as is rather than escaped, which
+	// keeps the output valid JavaScript. The JSON given to Raw is written as is, unless ASCIIOnly
+	// is set.
+	NoEscapeLineSeparators bool
+
+	// InvalidUTF8 is the way strings with invalid UTF-8 are written.
+	InvalidUTF8 InvalidUTF8Policy
+}
+
+// AppendTo makes the writer append the data to dst, growing it as a single slice rather than
+// a chain of pooled chunks, so that BuildBytes returns it without copying.
+func (w *Writer) AppendTo(dst []byte) {
+	w.Buffer.Buf = dst
+	w.Buffer.Single = true
 }
 
 // Size returns the size of the data that was written out.
@@ -75,6 +112,8 @@ func (w *Writer) Raw(data []byte, err error) {
 		return
 	case err != nil:
 		w.Error = err
+	case len(data) > 0 && w.ASCIIOnly:
+		w.rawASCII(data)
 	case len(data) > 0:
 		w.Buffer.AppendBytes(data)
 	default:
@@ -82,6 +121,27 @@ func (w *Writer) Raw(data []byte, err error) {
 	}
 }
 
+// rawASCII appends the JSON data to the buffer, escaping non-ASCII characters, which are in
+// strings.
+func (w *Writer) rawASCII(data []byte) {
+	p := 0
+	for i := 0; i < len(data); {
+		if data[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		w.Buffer.AppendBytes(data[p:i])
+		runeValue, runeWidth := utf8.DecodeRune(data[i:])
+		if runeValue == utf8.RuneError && runeWidth == 1 && w.InvalidUTF8 == ErrorOnInvalidUTF8 && w.Error == nil {
+			w.Error = ErrInvalidUTF8
+		}
+		w.escapeUTF16(runeValue)
+		i += runeWidth
+		p = i
+	}
+	w.Buffer.AppendBytes(data[p:])
+}
+
 // RawText encloses raw binary data in quotes and appends in to the buffer.
 // Useful for calling with results of MarshalText-like functions.
 func (w *Writer) RawText(data []byte, err error) {
@@ -108,6 +168,21 @@ func (w *Writer) Base64Bytes(data []byte) {
 	w.Buffer.AppendByte('"')
 }
 
+// HexBytes appends data to the buffer after lowercase hex encoding it
+func (w *Writer) HexBytes(data []byte) {
+	if data == nil {
+		w.Buffer.AppendString("null")
+		return
+	}
+	const hexChars = "0123456789abcdef"
+	w.Buffer.AppendByte('"')
+	for _, c := range data {
+		w.Buffer.EnsureSpace(2)
+		w.Buffer.Buf = append(w.Buffer.Buf, hexChars[c>>4], hexChars[c&0xf])
+	}
+	w.Buffer.AppendByte('"')
+}
+
 func (w *Writer) Uint8(n uint8) {
 	w.Buffer.EnsureSpace(3)
 	w.Buffer.Buf = strconv.AppendUint(w.Buffer.Buf, uint64(n), 10)
@@ -278,64 +353,101 @@ func (w *Writer) String(s string) {
 		escapeTable = &htmlNoEscapeTable
 	}
 
+	html := !w.NoEscapeHTML
 	for i := 0; i < len(s); {
-		c := s[i]
+		// Skip 8 bytes at a time while none of them needs escaping.
+		if i+8 <= len(s) && !needsEscape(load64(s[i:]), html) {
+			i += 8
+			continue
+		}
+
+		// Check the characters starting in the word one at a time.
+		end := i + 8
+		if end > len(s) {
+			end = len(s)
+		}
+		for i < end {
+			c := s[i]
+
+			if c < utf8.RuneSelf {
+				if escapeTable[c] {
+					// single-width character, no escaping is required
+					i++
+					continue
+				}
+
+				w.Buffer.AppendString(s[p:i])
+				switch c {
+				case '\t':
+					w.Buffer.AppendString(`\t`)
+				case '\r':
+					w.Buffer.AppendString(`\r`)
+				case '\n':
+					w.Buffer.AppendString(`\n`)
+				case '\\':
+					w.Buffer.AppendString(`\\`)
+				case '"':
+					w.Buffer.AppendString(`\"`)
+				default:
+					w.Buffer.AppendString(`\u00`)
+					w.Buffer.AppendByte(chars[c>>4])
+					w.Buffer.AppendByte(chars[c&0xf])
+				}
 
-		if c < utf8.RuneSelf {
-			if escapeTable[c] {
-				// single-width character, no escaping is required
 				i++
+				p = i
 				continue
 			}
 
-			w.Buffer.AppendString(s[p:i])
-			switch c {
-			case '\t':
-				w.Buffer.AppendString(`\t`)
-			case '\r':
-				w.Buffer.AppendString(`\r`)
-			case '\n':
-				w.Buffer.AppendString(`\n`)
-			case '\\':
-				w.Buffer.AppendString(`\\`)
-			case '"':
-				w.Buffer.AppendString(`\"`)
-			default:
-				w.Buffer.AppendString(`\u00`)
-				w.Buffer.AppendByte(chars[c>>4])
-				w.Buffer.AppendByte(chars[c&0xf])
+			// broken utf
+			runeValue, runeWidth := utf8.DecodeRuneInString(s[i:])
+			if runeValue == utf8.RuneError && runeWidth == 1 {
+				if w.InvalidUTF8 == ErrorOnInvalidUTF8 && w.Error == nil {
+					w.Error = ErrInvalidUTF8
+				}
+				w.Buffer.AppendString(s[p:i])
+				w.Buffer.AppendString(`\ufffd`)
+				i++
+				p = i
+				continue
 			}
 
-			i++
-			p = i
-			continue
-		}
-
-		// broken utf
-		runeValue, runeWidth := utf8.DecodeRuneInString(s[i:])
-		if runeValue == utf8.RuneError && runeWidth == 1 {
-			w.Buffer.AppendString(s[p:i])
-			w.Buffer.AppendString(`\ufffd`)
-			i++
-			p = i
-			continue
-		}
-
-		// jsonp stuff - tab separator and line separator
-		if runeValue == '\u2028' || runeValue == '\u2029' {
-			w.Buffer.AppendString(s[p:i])
-			w.Buffer.AppendString(`\u202`)
-			w.Buffer.AppendByte(chars[runeValue&0xf])
+			// jsonp stuff - tab separator and line separator
+			separator := runeValue == '\u2028' || runeValue == '\u2029'
+			if w.ASCIIOnly || separator && !w.NoEscapeLineSeparators {
+				w.Buffer.AppendString(s[p:i])
+				w.escapeUTF16(runeValue)
+				i += runeWidth
+				p = i
+				continue
+			}
 			i += runeWidth
-			p = i
-			continue
 		}
-		i += runeWidth
 	}
 	w.Buffer.AppendString(s[p:])
 	w.Buffer.AppendByte('"')
 }
 
+// escapeUTF16 appends the \uXXXX escape sequence of r, or of its UTF-16 surrogate pair above
+// U+FFFF.
+func (w *Writer) escapeUTF16(r rune) {
+	if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+		w.escapeRune(r1)
+		w.escapeRune(r2)
+	} else {
+		w.escapeRune(r)
+	}
+}
+
+// escapeRune appends the \uXXXX escape sequence of r, at most U+FFFF.
+func (w *Writer) escapeRune(r rune) {
+	w.Buffer.AppendString(`\u`)
+	w.Buffer.AppendByte(chars[r>>12&0xf])
+	w.Buffer.AppendByte(chars[r>>8&0xf])
+	w.Buffer.AppendByte(chars[r>>4&0xf])
+	w.Buffer.AppendByte(chars[r&0xf])
+}
+
 const encode = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
 const padChar = '='
 
@@ -0,0 +1,111 @@
+package tinyjson
+
+import "sort"
+
+// Kind is the kind of a type described by a TypeInfo.
+type Kind uint8
+
+// Kinds of the described types.
+const (
+	KindStruct Kind = iota
+	KindSlice
+	KindArray
+	KindMap
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindStruct:
+		return "struct"
+	case KindSlice:
+		return "slice"
+	case KindArray:
+		return "array"
+	case KindMap:
+		return "map"
+	}
+	return "invalid"
+}
+
+// TypeInfo describes how a type is mapped to JSON. It is generated as a static table by the
+// -metadata option, so that tools can inspect messages without relying on reflection.
+type TypeInfo struct {
+	Package string // Import path of the package declaring the type.
+	Name    string // Name of the type, with its type parameters for generic types, e.g. Paginated[T].
+	Kind    Kind
+
+	// Fields lists the fields of a struct type in encoding order, promoted fields of embedded
+	// structs included and fields tagged "-" excluded.
+	Fields []FieldInfo
+
+	// Elem describes the element type of a slice, array or map type, if it is a Describer.
+	Elem Describer
+}
+
+// FieldInfo describes a field of a struct type.
+type FieldInfo struct {
+	Name     string // Name of the Go field.
+	JSONName string // Member name the field is encoded with.
+	Type     string // Type of the field as written in the declaring package, e.g. []Coin.
+
+	OmitEmpty bool // Whether the field is omitted from the output when empty.
+	Required  bool // Whether decoding fails if the field is missing.
+	AsString  bool // Whether the value is encoded as a JSON string.
+
+	// Elem describes the type of the field's value, or the type it points to or holds elements
+	// of, if it is a Describer. It is nil for type parameters.
+	Elem Describer
+}
+
+// Field returns the field encoded with the given member name, or nil if there is none.
+func (t *TypeInfo) Field(jsonName string) *FieldInfo {
+	for i := range t.Fields {
+		if t.Fields[i].JSONName == jsonName {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Describer is implemented by the types generated with the -metadata option.
+type Describer interface {
+	TinyJSONTypeInfo() *TypeInfo
+}
+
+// TypeInfoOf returns the metadata of the type of v, or nil if v is not a Describer.
+func TypeInfoOf(v interface{}) *TypeInfo {
+	if d, ok := v.(Describer); ok {
+		return d.TinyJSONTypeInfo()
+	}
+	return nil
+}
+
+// typeInfos holds the registered metadata by package and type name.
+var typeInfos = map[string]*TypeInfo{}
+
+// RegisterTypeInfo adds the metadata of a type to the registry. It is called from the init
+// funcs of generated code and is not safe for concurrent use.
+func RegisterTypeInfo(info *TypeInfo) {
+	typeInfos[info.Package+"."+info.Name] = info
+}
+
+// LookupTypeInfo returns the registered metadata of the type declared in the given package with
+// the given name, or nil if there is none.
+func LookupTypeInfo(pkg, name string) *TypeInfo {
+	return typeInfos[pkg+"."+name]
+}
+
+// TypeInfos returns the metadata of all registered types, sorted by package and type name.
+func TypeInfos() []*TypeInfo {
+	keys := make([]string, 0, len(typeInfos))
+	for key := range typeInfos {
+		keys = append(keys, key)
+	}
+	// Sorting keys rather than using sort.Slice avoids reflection.
+	sort.Strings(keys)
+	infos := make([]*TypeInfo, len(keys))
+	for i, key := range keys {
+		infos[i] = typeInfos[key]
+	}
+	return infos
+}
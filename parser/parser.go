@@ -4,6 +4,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
 	"strings"
 )
@@ -11,6 +12,7 @@ import (
 const (
 	structComment     = "tinyjson:json"
 	structSkipComment = "tinyjson:skip"
+	aminoComment      = "tinyjson:amino"
 )
 
 type Parser struct {
@@ -18,6 +20,17 @@ type Parser struct {
 	PkgName     string
 	StructNames []string
 	AllStructs  bool
+
+	// AminoNames maps type names to the Amino JSON names given with a tinyjson:amino comment.
+	AminoNames map[string]string
+
+	// TypeOptions maps type names to the generator options given after their tinyjson:json
+	// comment, e.g. "snake_case".
+	TypeOptions map[string][]string
+
+	// TypeParams maps the names of generic types to their type parameters, each given as the
+	// name followed by the constraint, e.g. "T any".
+	TypeParams map[string][]string
 }
 
 type visitor struct {
@@ -26,11 +39,13 @@ type visitor struct {
 	name string
 }
 
-func (p *Parser) needType(comments *ast.CommentGroup) (skip, explicit bool) {
+// commentLines returns the trimmed lines of all comments in the group.
+func commentLines(comments *ast.CommentGroup) []string {
 	if comments == nil {
-		return
+		return nil
 	}
 
+	var lines []string
 	for _, v := range comments.List {
 		comment := v.Text
 
@@ -46,20 +61,57 @@ func (p *Parser) needType(comments *ast.CommentGroup) (skip, explicit bool) {
 		}
 
 		for _, comment := range strings.Split(comment, "\n") {
-			comment = strings.TrimSpace(comment)
+			lines = append(lines, strings.TrimSpace(comment))
+		}
+	}
+	return lines
+}
 
-			if strings.HasPrefix(comment, structSkipComment) {
-				return true, false
-			}
-			if strings.HasPrefix(comment, structComment) {
-				return false, true
-			}
+func (p *Parser) needType(comments *ast.CommentGroup) (skip, explicit bool) {
+	for _, comment := range commentLines(comments) {
+		if strings.HasPrefix(comment, structSkipComment) {
+			return true, false
+		}
+		if strings.HasPrefix(comment, structComment) {
+			return false, true
 		}
 	}
 
 	return
 }
 
+// aminoName returns the Amino JSON name given with a tinyjson:amino comment, if any.
+func (p *Parser) aminoName(comments *ast.CommentGroup) string {
+	for _, comment := range commentLines(comments) {
+		if strings.HasPrefix(comment, aminoComment+" ") {
+			return strings.TrimSpace(comment[len(aminoComment):])
+		}
+	}
+	return ""
+}
+
+// typeOptions returns the generator options given after the tinyjson:json comment, if any.
+func (p *Parser) typeOptions(comments *ast.CommentGroup) []string {
+	for _, comment := range commentLines(comments) {
+		if strings.HasPrefix(comment, structComment+" ") {
+			return strings.Fields(comment[len(structComment):])
+		}
+	}
+	return nil
+}
+
+// typeParams returns the type parameters of a generic type declaration.
+func typeParams(fields *ast.FieldList) []string {
+	var params []string
+	for _, f := range fields.List {
+		constraint := types.ExprString(f.Type)
+		for _, name := range f.Names {
+			params = append(params, name.Name+" "+constraint)
+		}
+	}
+	return params
+}
+
 func (v *visitor) Visit(n ast.Node) (w ast.Visitor) {
 	switch n := n.(type) {
 	case *ast.Package:
@@ -91,6 +143,24 @@ func (v *visitor) Visit(n ast.Node) (w ast.Visitor) {
 		}
 
 		v.name = n.Name.String()
+		if name := v.aminoName(n.Doc); name != "" {
+			if v.AminoNames == nil {
+				v.AminoNames = make(map[string]string)
+			}
+			v.AminoNames[v.name] = name
+		}
+		if options := v.typeOptions(n.Doc); len(options) > 0 {
+			if v.TypeOptions == nil {
+				v.TypeOptions = make(map[string][]string)
+			}
+			v.TypeOptions[v.name] = options
+		}
+		if n.TypeParams != nil {
+			if v.TypeParams == nil {
+				v.TypeParams = make(map[string][]string)
+			}
+			v.TypeParams[v.name] = typeParams(n.TypeParams)
+		}
 
 		// Allow to specify non-structs explicitly independent of '-all' flag.
 		if explicit {
@@ -0,0 +1,100 @@
+package tinyjson
+
+import (
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// ProtoAnyCodec converts the protobuf-encoded value of a google.protobuf.Any to and from its
+// proto3 JSON representation.
+type ProtoAnyCodec interface {
+	// MarshalAnyJSON writes the JSON object for the protobuf-encoded message.
+	MarshalAnyJSON(w *jwriter.Writer, value []byte)
+	// UnmarshalAnyJSON reads the JSON object of a message and returns it protobuf-encoded. The
+	// object still contains the "@type" member, which should be skipped.
+	UnmarshalAnyJSON(l *jlexer.Lexer) []byte
+}
+
+// Registered codecs for google.protobuf.Any values: type URL -> codec.
+var protoAnyCodecs = map[string]ProtoAnyCodec{}
+
+// RegisterProtoAny registers the codec used for google.protobuf.Any values with the given type
+// URL. It is not safe for concurrent use and is intended to be called from init functions.
+func RegisterProtoAny(typeURL string, codec ProtoAnyCodec) {
+	protoAnyCodecs[typeURL] = codec
+}
+
+// EncodeProtoAny writes a google.protobuf.Any value in the proto3 JSON format, i.e. as the JSON
+// object of the embedded message with an additional "@type" member.
+func EncodeProtoAny(w *jwriter.Writer, typeURL string, value []byte) {
+	codec := protoAnyCodecs[typeURL]
+	if codec == nil {
+		if w.Error == nil {
+			w.Error = jlexer.NewError("tinyjson: no codec registered for Any type " + typeURL)
+		}
+		return
+	}
+
+	inner := jwriter.Writer{
+		Flags:                  w.Flags,
+		NoEscapeHTML:           w.NoEscapeHTML,
+		ASCIIOnly:              w.ASCIIOnly,
+		NoEscapeLineSeparators: w.NoEscapeLineSeparators,
+		InvalidUTF8:            w.InvalidUTF8,
+	}
+	codec.MarshalAnyJSON(&inner, value)
+	data, err := inner.BuildBytes()
+	if err != nil {
+		w.Raw(nil, err)
+		return
+	}
+
+	w.RawString(`{"@type":`)
+	w.String(typeURL)
+	// Splice the members of the message object after "@type".
+	if len(data) > 2 && data[0] == '{' {
+		w.RawByte(',')
+		w.Raw(data[1:], nil)
+	} else {
+		w.RawByte('}')
+	}
+}
+
+// DecodeProtoAny reads a google.protobuf.Any value in the proto3 JSON format.
+func DecodeProtoAny(l *jlexer.Lexer) (typeURL string, value []byte) {
+	data := l.Raw()
+	if !l.Ok() {
+		return "", nil
+	}
+
+	// Find the "@type" member first, as it can appear anywhere in the object.
+	scan := jlexer.Lexer{Data: data}
+	scan.Delim('{')
+	for !scan.IsDelim('}') {
+		key := scan.UnsafeFieldName(false)
+		scan.WantColon()
+		if key == "@type" {
+			typeURL = scan.String()
+			break
+		}
+		scan.SkipRecursive()
+		scan.WantComma()
+	}
+	if err := scan.Error(); err != nil {
+		l.AddError(err)
+		return "", nil
+	}
+
+	codec := protoAnyCodecs[typeURL]
+	if codec == nil {
+		l.AddNonFatalError(jlexer.NewError("no codec registered for Any type " + typeURL))
+		return typeURL, nil
+	}
+
+	inner := jlexer.Lexer{Data: data}
+	value = codec.UnmarshalAnyJSON(&inner)
+	if err := inner.Error(); err != nil {
+		l.AddError(err)
+	}
+	return typeURL, value
+}
@@ -0,0 +1,155 @@
+// Package reflectparam lets generic types be instantiated with floats and with named types of
+// primitive kinds, e.g. type Denom string, which tinyjson.EncodeTypeParam and
+// tinyjson.DecodeTypeParam do not support themselves. Importing it registers a
+// tinyjson.TypeParamCodec relying on reflection and floats, so it is meant for host programs
+// rather than contracts:
+//
+//	import _ "github.com/CosmWasm/tinyjson/reflectparam"
+package reflectparam
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// ErrUnsupportedFloat is reported when a NaN or infinite float is encoded, as JSON has no
+// representation for them.
+var ErrUnsupportedFloat = errors.New("tinyjson: unsupported float value")
+
+func init() {
+	tinyjson.RegisterTypeParamCodec(Codec{})
+}
+
+// Codec is the tinyjson.TypeParamCodec registered by the package. It encodes and decodes values
+// according to their kinds: strings, bools, integers, floats and byte slices.
+type Codec struct{}
+
+// EncodeTypeParam implements tinyjson.TypeParamCodec.
+func (Codec) EncodeTypeParam(w *jwriter.Writer, v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		w.String(rv.String())
+	case reflect.Bool:
+		w.Bool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.Int64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		w.Uint64(rv.Uint())
+	case reflect.Float32:
+		encodeFloat(w, rv.Float(), 32)
+	case reflect.Float64:
+		encodeFloat(w, rv.Float(), 64)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			return false
+		}
+		if rv.IsNil() {
+			w.RawString("null")
+		} else {
+			w.Base64Bytes(rv.Bytes())
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// encodeFloat writes the float f of the given bit size to w, formatted like encoding/json does.
+func encodeFloat(w *jwriter.Writer, f float64, bits int) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		if w.Error == nil {
+			w.Error = ErrUnsupportedFloat
+		}
+		return
+	}
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 && (bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21)) {
+		format = 'e'
+	}
+	s := strconv.FormatFloat(f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9, as encoding/json does.
+		if n := len(s); n >= 4 && s[n-4] == 'e' && s[n-3] == '-' && s[n-2] == '0' {
+			s = s[:n-2] + s[n-1:]
+		}
+	}
+	w.RawString(s)
+}
+
+// DecodeTypeParam implements tinyjson.TypeParamCodec.
+func (Codec) DecodeTypeParam(l *jlexer.Lexer, v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return false
+	}
+	e := rv.Elem()
+	switch e.Kind() {
+	case reflect.String:
+		e.SetString(l.String())
+	case reflect.Bool:
+		e.SetBool(l.Bool())
+	case reflect.Int:
+		e.SetInt(int64(l.Int()))
+	case reflect.Int8:
+		e.SetInt(int64(l.Int8()))
+	case reflect.Int16:
+		e.SetInt(int64(l.Int16()))
+	case reflect.Int32:
+		e.SetInt(int64(l.Int32()))
+	case reflect.Int64:
+		e.SetInt(l.Int64())
+	case reflect.Uint:
+		e.SetUint(uint64(l.Uint()))
+	case reflect.Uint8:
+		e.SetUint(uint64(l.Uint8()))
+	case reflect.Uint16:
+		e.SetUint(uint64(l.Uint16()))
+	case reflect.Uint32:
+		e.SetUint(uint64(l.Uint32()))
+	case reflect.Uint64:
+		e.SetUint(l.Uint64())
+	case reflect.Float32:
+		e.SetFloat(decodeFloat(l, 32))
+	case reflect.Float64:
+		e.SetFloat(decodeFloat(l, 64))
+	case reflect.Slice:
+		if e.Type().Elem().Kind() != reflect.Uint8 {
+			return false
+		}
+		if l.IsNull() {
+			l.Skip()
+			e.SetBytes(nil)
+		} else {
+			e.SetBytes(l.Bytes())
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// decodeFloat reads the next number from l as a float of the given bit size.
+func decodeFloat(l *jlexer.Lexer, bits int) float64 {
+	s := l.Number()
+	if !l.Ok() || s == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(s, bits)
+	if err != nil {
+		l.AddNonFatalError(&jlexer.LexerError{
+			Offset: l.TokenStart(),
+			Reason: err.Error(),
+			Err:    jlexer.ErrOverflow,
+			Data:   s,
+		})
+	}
+	return f
+}
@@ -0,0 +1,83 @@
+package reflectparam_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/reflectparam"
+	"github.com/CosmWasm/tinyjson/tests"
+)
+
+// Named type arguments without methods are encoded according to their kinds.
+type (
+	denom  string
+	height uint32
+	blob   []byte
+)
+
+func TestTypeParams(t *testing.T) {
+	for _, test := range []struct {
+		v    tinyjson.MarshalerUnmarshaler
+		new  func() tinyjson.MarshalerUnmarshaler
+		json string
+	}{
+		{
+			v:    &tests.Pair[string, float64]{Key: "pi", Value: 3.14},
+			new:  func() tinyjson.MarshalerUnmarshaler { return &tests.Pair[string, float64]{} },
+			json: `{"key":"pi","value":3.14}`,
+		},
+		{
+			v:    &tests.Pair[float32, float64]{Key: 1e-7, Value: 1e21},
+			new:  func() tinyjson.MarshalerUnmarshaler { return &tests.Pair[float32, float64]{} },
+			json: `{"key":1e-7,"value":1e+21}`,
+		},
+		{
+			v:    &tests.Pair[denom, height]{Key: "uatom", Value: 42},
+			new:  func() tinyjson.MarshalerUnmarshaler { return &tests.Pair[denom, height]{} },
+			json: `{"key":"uatom","value":42}`,
+		},
+		{
+			v:    &tests.Pair[bool, blob]{Key: true, Value: blob("hi")},
+			new:  func() tinyjson.MarshalerUnmarshaler { return &tests.Pair[bool, blob]{} },
+			json: `{"key":true,"value":"aGk="}`,
+		},
+	} {
+		data, err := tinyjson.Marshal(test.v)
+		if err != nil {
+			t.Errorf("tinyjson.Marshal(%T) error: %v", test.v, err)
+			continue
+		}
+		if string(data) != test.json {
+			t.Errorf("tinyjson.Marshal(%T) = %s; want %s", test.v, data, test.json)
+		}
+
+		got := test.new()
+		if err := tinyjson.Unmarshal([]byte(test.json), got); err != nil {
+			t.Errorf("tinyjson.Unmarshal(%T) error: %v", got, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.v) {
+			t.Errorf("tinyjson.Unmarshal(%T) = %+v; want %+v", got, got, test.v)
+		}
+	}
+}
+
+func TestTypeParamErrors(t *testing.T) {
+	_, err := tinyjson.Marshal(tests.Pair[string, []string]{Key: "denoms", Value: []string{"uatom"}})
+	if !errors.Is(err, tinyjson.ErrUnsupportedTypeParam) {
+		t.Errorf("tinyjson.Marshal() error = %v; want %v", err, tinyjson.ErrUnsupportedTypeParam)
+	}
+
+	if _, err := tinyjson.Marshal(tests.Pair[string, float64]{Key: "nan", Value: math.NaN()}); !errors.Is(err, reflectparam.ErrUnsupportedFloat) {
+		t.Errorf("tinyjson.Marshal() of NaN error = %v; want %v", err, reflectparam.ErrUnsupportedFloat)
+	}
+
+	var f tests.Pair[string, float32]
+	err = tinyjson.Unmarshal([]byte(`{"key":"big","value":1e39}`), &f)
+	if !errors.Is(err, tinyjson.ErrOverflow) {
+		t.Errorf("tinyjson.Unmarshal() of 1e39 error = %v; want %v", err, tinyjson.ErrOverflow)
+	}
+}
@@ -0,0 +1,61 @@
+package tests
+
+import "github.com/CosmWasm/tinyjson"
+
+//tinyjson:json
+type AminoSignDoc struct {
+	ChainID       string            `json:"chain_id"`
+	AccountNumber uint64            `json:"account_number"`
+	Sequence      uint64            `json:"sequence"`
+	TimeoutHeight int64             `json:"timeout_height,omitempty"`
+	Fee           AminoFee          `json:"fee"`
+	Msgs          []AminoMsg        `json:"msgs"`
+	Memo          string            `json:"memo"`
+	Labels        map[string]string `json:"labels,omitempty"`
+}
+
+type AminoMsg interface {
+	tinyjson.MarshalerUnmarshaler
+}
+
+type AminoFee struct {
+	Gas     uint64      `json:"gas"`
+	Amount  []AminoCoin `json:"amount"`
+	Payer   string      `json:"payer,omitempty"`
+	Granter AminoCoin   `json:"granter,omitempty"`
+}
+
+type AminoCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+//tinyjson:json
+//tinyjson:amino cosmos-sdk/MsgSend
+type AminoMsgSend struct {
+	FromAddress string      `json:"from_address"`
+	ToAddress   string      `json:"to_address"`
+	Amount      []AminoCoin `json:"amount"`
+}
+
+var aminoSignDocValue = AminoSignDoc{
+	ChainID:       "cosmoshub-4",
+	AccountNumber: 1,
+	Sequence:      7,
+	Fee: AminoFee{
+		Gas:    200000,
+		Amount: []AminoCoin{{Denom: "uatom", Amount: "5000"}},
+	},
+	Msgs: []AminoMsg{&AminoMsgSend{
+		FromAddress: "cosmos1from",
+		ToAddress:   "cosmos1to",
+		Amount:      []AminoCoin{{Denom: "uatom", Amount: "10"}},
+	}},
+	Labels: map[string]string{"z": "last", "a": "first"},
+}
+
+var aminoSignDocString = `{"account_number":"1","chain_id":"cosmoshub-4",` +
+	`"fee":{"amount":[{"amount":"5000","denom":"uatom"}],"gas":"200000"},` +
+	`"labels":{"a":"first","z":"last"},"memo":"",` +
+	`"msgs":[{"type":"cosmos-sdk/MsgSend","value":{"amount":[{"amount":"10","denom":"uatom"}],"from_address":"cosmos1from","to_address":"cosmos1to"}}],` +
+	`"sequence":"7"}`
@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+func TestAminoJSON(t *testing.T) {
+	data, err := tinyjson.Marshal(aminoSignDocValue)
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() error: %v", err)
+	}
+	if string(data) != aminoSignDocString {
+		t.Errorf("tinyjson.Marshal() = %s; want %s", data, aminoSignDocString)
+	}
+}
+
+func TestAminoName(t *testing.T) {
+	var v tinyjson.Marshaler = AminoMsgSend{}
+	n, ok := v.(tinyjson.AminoNamer)
+	if !ok {
+		t.Fatalf("AminoMsgSend does not implement tinyjson.AminoNamer")
+	}
+	if got := n.AminoName(); got != "cosmos-sdk/MsgSend" {
+		t.Errorf("AminoName() = %q; want %q", got, "cosmos-sdk/MsgSend")
+	}
+}
+
+func TestAminoNilInterface(t *testing.T) {
+	v := AminoSignDoc{Msgs: []AminoMsg{nil}}
+	data, err := tinyjson.Marshal(v)
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() error: %v", err)
+	}
+	want := `{"account_number":"0","chain_id":"","fee":{"amount":null,"gas":"0"},"memo":"","msgs":[null],"sequence":"0"}`
+	if string(data) != want {
+		t.Errorf("tinyjson.Marshal() = %s; want %s", data, want)
+	}
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+func TestAppendTinyJSON(t *testing.T) {
+	dst := make([]byte, 0, 1024)
+	dst = append(dst, "prefix:"...)
+
+	got := binaryStructValue.AppendTinyJSON(dst)
+	if want := "prefix:" + binaryStructString; string(got) != want {
+		t.Errorf("AppendTinyJSON() = %s; want %s", got, want)
+	}
+	if &got[0] != &dst[0] {
+		t.Error("AppendTinyJSON() did not write to the supplied slice")
+	}
+
+	// A too small slice is grown.
+	got = binaryStructValue.AppendTinyJSON(make([]byte, 0, 2))
+	if string(got) != binaryStructString {
+		t.Errorf("AppendTinyJSON() = %s; want %s", got, binaryStructString)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	got, err := tinyjson.Append([]byte("["), paginatedValue)
+	if err != nil {
+		t.Fatalf("tinyjson.Append() error: %v", err)
+	}
+	if want := "[" + paginatedString; string(got) != want {
+		t.Errorf("tinyjson.Append() = %s; want %s", got, want)
+	}
+
+	got, err = tinyjson.Append([]byte("["), (*BinaryStruct)(nil))
+	if err != nil || string(got) != "[null" {
+		t.Errorf("tinyjson.Append(nil) = %s, %v; want [null", got, err)
+	}
+
+	dst := []byte("[")
+	got, err = tinyjson.Append(dst, Pair[string, float64]{})
+	if err == nil || string(got) != "[" {
+		t.Errorf("tinyjson.Append() = %s, %v; want [ and an error", got, err)
+	}
+}
+
+func BenchmarkAppendTinyJSON(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 1024)
+	for i := 0; i < b.N; i++ {
+		buf = paginatedValue.AppendTinyJSON(buf[:0])
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := tinyjson.Marshal(paginatedValue); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -25,6 +25,14 @@ var testCases = []struct {
 	{&omitEmptyValue, omitEmptyString},
 	{&snakeStructValue, snakeStructString},
 	{&omitEmptyDefaultValue, omitEmptyDefaultString},
+	{&snakeOverrideStructValue, snakeOverrideStructString},
+	{&omitEmptyOverrideValue, omitEmptyOverrideString},
+	{&typeOptionsSnakeValue, typeOptionsSnakeString},
+	{&typeOptionsCamelValue, typeOptionsCamelString},
+	{&namingKebabValue, namingKebabString},
+	{&namingScreamingValue, namingScreamingString},
+	{&namingPascalValue, namingPascalString},
+	{&codecsValue, codecsString},
 	{&optsValue, optsString},
 	{&rawValue, rawString},
 	{&stdMarshalerValue, stdMarshalerString},
@@ -268,6 +276,18 @@ func TestUnmarshalStructWithEmbeddedPtrStruct(t *testing.T) {
 	}
 }
 
+func TestTypeOptionsDisallowUnknown(t *testing.T) {
+	var v TypeOptionsSnake
+	err := tinyjson.Unmarshal([]byte(`{"some_value":1,"SomeValue":2}`), &v)
+	if err == nil {
+		t.Error("Unmarshal() of a member unknown to a type with disallow_unknown: want error, got nil")
+	}
+	var d TypeOptionsDefault
+	if err := tinyjson.Unmarshal([]byte(`{"SomeValue":2,"other":3}`), &d); err != nil {
+		t.Errorf("Unmarshal() of a member unknown to a type with default options error: %v", err)
+	}
+}
+
 func TestDisallowUnknown(t *testing.T) {
 	var d DisallowUnknown
 	err := tinyjson.Unmarshal([]byte(disallowUnknownString), &d)
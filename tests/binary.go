@@ -0,0 +1,27 @@
+package tests
+
+import "github.com/CosmWasm/tinyjson"
+
+//tinyjson:json
+type BinaryStruct struct {
+	Data      tinyjson.Binary    `json:"data"`
+	Checksum  tinyjson.HexBinary `json:"checksum"`
+	Hash      [32]byte           `json:"hash,hex"`
+	Pubkey    []byte             `json:"pubkey,hex,omitempty"`
+	Signature [4]byte            `json:"signature,base64"`
+	Msg       []byte             `json:"msg,base64,omitempty"`
+	Optional  tinyjson.Binary    `json:"optional,omitempty"`
+}
+
+var binaryStructValue = BinaryStruct{
+	Data:      tinyjson.Binary("hello"),
+	Checksum:  tinyjson.HexBinary{0xde, 0xad, 0xbe, 0xef},
+	Hash:      [32]byte{0: 0x01, 31: 0xff},
+	Pubkey:    []byte{0x02, 0xab},
+	Signature: [4]byte{1, 2, 3, 4},
+	Msg:       []byte("{}"),
+}
+
+var binaryStructString = `{"data":"aGVsbG8=","checksum":"deadbeef",` +
+	`"hash":"01000000000000000000000000000000000000000000000000000000000000ff",` +
+	`"pubkey":"02ab","signature":"AQIDBA==","msg":"e30="}`
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+func TestBinaryMarshal(t *testing.T) {
+	data, err := tinyjson.Marshal(binaryStructValue)
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() error: %v", err)
+	}
+	if string(data) != binaryStructString {
+		t.Errorf("tinyjson.Marshal() = %s; want %s", data, binaryStructString)
+	}
+
+	var got BinaryStruct
+	if err := tinyjson.Unmarshal(data, &got); err != nil {
+		t.Fatalf("tinyjson.Unmarshal() error: %v", err)
+	}
+	if !reflect.DeepEqual(got, binaryStructValue) {
+		t.Errorf("tinyjson.Unmarshal() = %+v; want %+v", got, binaryStructValue)
+	}
+}
+
+func TestBinaryEmpty(t *testing.T) {
+	data, err := tinyjson.Marshal(BinaryStruct{})
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() error: %v", err)
+	}
+	want := `{"data":"","checksum":"",` +
+		`"hash":"0000000000000000000000000000000000000000000000000000000000000000",` +
+		`"signature":"AAAAAA=="}`
+	if string(data) != want {
+		t.Errorf("tinyjson.Marshal() = %s; want %s", data, want)
+	}
+}
+
+func TestBinaryUnmarshalErrors(t *testing.T) {
+	for i, data := range []string{
+		`{"data":"aGVsbG8"}`,
+		`{"data":"aGVsbG9="}`,
+		`{"data":"aGVs\nbG8="}`,
+		`{"data":"aGVsbG8-"}`,
+		`{"checksum":"DEADBEEF"}`,
+		`{"checksum":"dead0"}`,
+		`{"hash":"01"}`,
+		`{"signature":"AQIDBAU="}`,
+		`{"pubkey":"0x02ab"}`,
+	} {
+		var got BinaryStruct
+		if err := tinyjson.Unmarshal([]byte(data), &got); err == nil {
+			t.Errorf("[%d] tinyjson.Unmarshal(%s) expected error", i, data)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// Codecs of big.Int and time.Duration are registered in codecs.json.
+//
+//tinyjson:json
+type Codecs struct {
+	Amount    big.Int
+	Max       *big.Int `json:",omitempty"`
+	Amounts   []big.Int
+	Timeout   time.Duration
+	Timeouts  map[time.Duration]string
+	Intervals []time.Duration
+}
+
+// encodeBigInt writes the integer as a string, like Uint128 in CosmWasm.
+func encodeBigInt(out *jwriter.Writer, v *big.Int) {
+	out.String(v.String())
+}
+
+func decodeBigInt(in *jlexer.Lexer, v *big.Int) {
+	s := in.String()
+	if _, ok := v.SetString(s, 10); !ok && in.Ok() {
+		in.AddError(fmt.Errorf("invalid integer %q", s))
+	}
+}
+
+func decodeDuration(in *jlexer.Lexer) time.Duration {
+	s := in.String()
+	d, err := time.ParseDuration(s)
+	if err != nil && in.Ok() {
+		in.AddError(err)
+	}
+	return d
+}
+
+var codecsValue = Codecs{
+	Amount:    *big.NewInt(1000),
+	Max:       new(big.Int).Lsh(big.NewInt(1), 100),
+	Amounts:   []big.Int{*big.NewInt(1), *big.NewInt(-2)},
+	Timeout:   90 * time.Second,
+	Timeouts:  map[time.Duration]string{time.Second: "short"},
+	Intervals: []time.Duration{time.Millisecond, time.Hour},
+}
+var codecsString = `{"Amount":"1000","Max":"1267650600228229401496703205376","Amounts":["1","-2"],"Timeout":"1m30s","Timeouts":{"1s":"short"},"Intervals":["1ms","1h0m0s"]}`
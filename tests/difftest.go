@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/opt"
+)
+
+//tinyjson:json
+type DiffOrder struct {
+	ID       uint64            `json:"id,string"`
+	Amount   int64             `json:"amount,string,omitempty"`
+	Owner    string            `json:"owner"`
+	Memo     string            `json:"memo,omitempty"`
+	Tags     []string          `json:"tags"`
+	Labels   []string          `json:"labels,omitempty"`
+	Attrs    map[string]string `json:"attrs"`
+	Payload  []byte            `json:"payload"`
+	Data     tinyjson.Binary   `json:"data,omitempty"`
+	Limit    *uint32           `json:"limit,omitempty"`
+	Items    []DiffItem        `json:"items"`
+	Next     *DiffOrder        `json:"next,omitempty"`
+	Disabled bool
+	ignored  int
+}
+
+//tinyjson:json
+type DiffItem struct {
+	DiffBase
+	Denom  string         `json:"denom"`
+	Counts map[int]uint16 `json:"counts,omitempty"`
+	Max    opt.Int64      `json:"max"`
+}
+
+type DiffBase struct {
+	Kind  uint8 `json:"kind"`
+	Level int8
+}
+
+//tinyjson:json
+type DiffHexDigest struct {
+	Digest []byte `json:"digest,hex"`
+	Size   int    `json:"size"`
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/bech32"
+	"github.com/CosmWasm/tinyjson/jlexer"
+)
+
+func TestErrorKinds(t *testing.T) {
+	for i, test := range []struct {
+		Data string
+		V    tinyjson.Unmarshaler
+		Kind error
+	}{
+		{Data: `{"int": 1,}`, V: &ErrorStruct{}, Kind: tinyjson.ErrSyntax},
+		{Data: `{"int": "1"}`, V: &ErrorStruct{}, Kind: tinyjson.ErrTypeMismatch},
+		{Data: `{"int": 1.5}`, V: &ErrorStruct{}, Kind: tinyjson.ErrTypeMismatch},
+		{Data: `{"300": "x", "5000000000": "y"}`, V: &ErrorIntMap{}, Kind: tinyjson.ErrOverflow},
+		{Data: `{"int": 1} {}`, V: &ErrorStruct{}, Kind: tinyjson.ErrTrailingData},
+		{Data: disallowUnknownString, V: &DisallowUnknown{}, Kind: tinyjson.ErrUnknownField},
+		{Data: `{}`, V: &RequiredOptionalStruct{}, Kind: tinyjson.ErrRequiredField},
+		{Data: `{"data":"aGVsbG8"}`, V: &BinaryStruct{}, Kind: tinyjson.ErrInvalidValue},
+		{Data: `"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsk"`, V: new(tinyjson.Addr), Kind: tinyjson.ErrInvalidValue},
+	} {
+		err := tinyjson.Unmarshal([]byte(test.Data), test.V)
+		if !errors.Is(err, test.Kind) {
+			t.Errorf("[%d] tinyjson.Unmarshal(%s) error = %v; want %v", i, test.Data, err, test.Kind)
+		}
+		var lerr *jlexer.LexerError
+		if !errors.As(err, &lerr) {
+			t.Errorf("[%d] tinyjson.Unmarshal(%s) error = %#v; want *jlexer.LexerError", i, test.Data, err)
+		}
+	}
+}
+
+func TestAddrErrorCause(t *testing.T) {
+	for _, test := range []struct {
+		Data  string
+		Cause error
+	}{
+		{Data: `"COSMOS1WD5KWMN9WGKKZERYWFJHXUEDXQCRQVP3HDYCSJ"`, Cause: tinyjson.ErrAddrNotLowercase},
+		{Data: `"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsk"`, Cause: bech32.ErrChecksum},
+	} {
+		var a tinyjson.Addr
+		err := tinyjson.Unmarshal([]byte(test.Data), &a)
+		if !errors.Is(err, tinyjson.ErrInvalidValue) || !errors.Is(err, test.Cause) {
+			t.Errorf("tinyjson.Unmarshal(%s) error = %v; want ErrInvalidValue caused by %v", test.Data, err, test.Cause)
+		}
+	}
+
+	l := jlexer.Lexer{Data: []byte(`"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj"`), AddrPrefix: "wasm"}
+	var a tinyjson.Addr
+	a.UnmarshalTinyJSON(&l)
+	if err := l.Error(); !errors.Is(err, tinyjson.ErrInvalidValue) || !errors.Is(err, tinyjson.ErrAddrPrefix) {
+		t.Errorf("Addr.UnmarshalTinyJSON() error = %v; want ErrInvalidValue caused by ErrAddrPrefix", err)
+	}
+}
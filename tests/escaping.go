@@ -9,3 +9,9 @@ type EscStringStruct struct {
 type EscIntStruct struct {
 	A int `json:"a,string"`
 }
+
+//tinyjson:json
+type EscNamesStruct struct {
+	Amount string `json:"montant_é"`
+	Emoji  int    `json:"😀"`
+}
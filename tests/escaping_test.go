@@ -1,10 +1,14 @@
 package tests
 
 import (
+	"errors"
+	"math/rand"
 	"reflect"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/jwriter"
 )
 
 func TestStrFieldsUnescaping(t *testing.T) {
@@ -88,3 +92,125 @@ func TestIntFieldsUnescaping(t *testing.T) {
 		}
 	}
 }
+
+// TestWriterStringWords checks that strings are escaped the same whether their characters are
+// checked by words or one at a time.
+func TestWriterStringWords(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	pieces := []string{"a", "b", " ", `"`, `\`, "<", ">", "&", "\n", "\x01", "\x7f", "é", " ", "😀", "\xff"}
+	for i := 0; i < 2000; i++ {
+		var s string
+		for n := rnd.Intn(40); n > 0; n-- {
+			if rnd.Intn(3) == 0 {
+				s += pieces[rnd.Intn(len(pieces))]
+			} else {
+				s += "abcdefgh"[:1+rnd.Intn(8)]
+			}
+		}
+
+		for _, config := range []jwriter.Writer{
+			{},
+			{NoEscapeHTML: true},
+			{ASCIIOnly: true, NoEscapeLineSeparators: true},
+		} {
+			// Characters written separately never fill a word.
+			want := config
+			want.RawByte('"')
+			for j := 0; j < len(s); {
+				_, n := utf8.DecodeRuneInString(s[j:])
+				one := config
+				one.String(s[j : j+n])
+				data, _ := one.BuildBytes()
+				want.Raw(data[1:len(data)-1], nil)
+				j += n
+			}
+			want.RawByte('"')
+
+			got := config
+			got.String(s)
+			gotData, _ := got.BuildBytes()
+			wantData, _ := want.BuildBytes()
+			if string(gotData) != string(wantData) {
+				t.Fatalf("String(%q) = %s; want %s", s, gotData, wantData)
+			}
+		}
+	}
+}
+
+func TestWriterStringEscaping(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		writer jwriter.Writer
+		in     string
+		want   string
+	}{
+		{"default", jwriter.Writer{}, "é<\u2028😀", `"é\u003c\u2028😀"`},
+		{"raw separators", jwriter.Writer{NoEscapeLineSeparators: true}, "a\u2028b\u2029", "\"a\u2028b\u2029\""},
+		{"ascii only", jwriter.Writer{ASCIIOnly: true}, "aé中\u2029", `"a\u00e9\u4e2d\u2029"`},
+		{"surrogate pair", jwriter.Writer{ASCIIOnly: true}, "😀x", `"\ud83d\ude00x"`},
+		{"ascii only raw separators", jwriter.Writer{ASCIIOnly: true, NoEscapeLineSeparators: true}, "\u2028", `"\u2028"`},
+		{"invalid utf8", jwriter.Writer{ASCIIOnly: true}, "a\xffb", `"a\ufffdb"`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			w := test.writer
+			w.String(test.in)
+			got, err := w.BuildBytes()
+			if err != nil || string(got) != test.want {
+				t.Errorf("String(%q) = %s, %v; want %s", test.in, got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestWriterRawASCIIOnly(t *testing.T) {
+	msg := tinyjson.RawMessage("{\"memo\":\"é\u2028😀\",\"n\":1}")
+
+	w := jwriter.Writer{ASCIIOnly: true}
+	msg.MarshalTinyJSON(&w)
+	got, err := w.BuildBytes()
+	if want := `{"memo":"\u00e9\u2028\ud83d\ude00","n":1}`; err != nil || string(got) != want {
+		t.Errorf("Raw() = %s, %v; want %s", got, err, want)
+	}
+
+	w = jwriter.Writer{ASCIIOnly: true, InvalidUTF8: jwriter.ErrorOnInvalidUTF8}
+	w.Raw([]byte("\"in\xc3valid\""), nil)
+	if _, err := w.BuildBytes(); !errors.Is(err, jwriter.ErrInvalidUTF8) {
+		t.Errorf("BuildBytes() error = %v; want ErrInvalidUTF8", err)
+	}
+}
+
+func TestWriterASCIIOnlyMemberNames(t *testing.T) {
+	v := EscNamesStruct{Amount: "ü", Emoji: 1}
+
+	w := jwriter.Writer{}
+	v.MarshalTinyJSON(&w)
+	got, err := w.BuildBytes()
+	if want := `{"montant_é":"ü","😀":1}`; err != nil || string(got) != want {
+		t.Errorf("MarshalTinyJSON() = %s, %v; want %s", got, err, want)
+	}
+
+	w = jwriter.Writer{ASCIIOnly: true}
+	v.MarshalTinyJSON(&w)
+	got, err = w.BuildBytes()
+	if want := `{"montant_\u00e9":"\u00fc","\ud83d\ude00":1}`; err != nil || string(got) != want {
+		t.Errorf("MarshalTinyJSON() with ASCIIOnly = %s, %v; want %s", got, err, want)
+	}
+
+	var decoded EscNamesStruct
+	if err := tinyjson.Unmarshal(got, &decoded); err != nil || decoded != v {
+		t.Errorf("tinyjson.Unmarshal(%s) = %+v, %v; want %+v", got, decoded, err, v)
+	}
+}
+
+func TestWriterInvalidUTF8Policy(t *testing.T) {
+	w := jwriter.Writer{InvalidUTF8: jwriter.ErrorOnInvalidUTF8}
+	w.String("valid é")
+	if _, err := w.BuildBytes(); err != nil {
+		t.Fatalf("BuildBytes() error = %v for valid UTF-8", err)
+	}
+
+	w.String("in\xc3valid")
+	if _, err := w.BuildBytes(); !errors.Is(err, jwriter.ErrInvalidUTF8) {
+		t.Errorf("BuildBytes() error = %v; want ErrInvalidUTF8", err)
+	}
+}
@@ -0,0 +1,14 @@
+package tests
+
+import "github.com/CosmWasm/tinyjson/typegen"
+
+// ExternalHolder has fields of types declared in another package, encoded with the functions
+// generated for them with -external.
+//
+//tinyjson:json
+type ExternalHolder struct {
+	Decl   typegen.Decl
+	Fields []typegen.Field
+	Ptr    *typegen.Field `json:",omitempty"`
+	ByName map[string]typegen.Field
+}
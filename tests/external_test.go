@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+	"github.com/CosmWasm/tinyjson/typegen"
+)
+
+const externalFieldString = `{"Name":"Denom","Type":"string","JSONName":"denom","Doc":"","OmitEmpty":false,"Required":true,"AsString":false}`
+
+func TestExternalFuncs(t *testing.T) {
+	field := typegen.Field{Name: "Denom", Type: "string", JSONName: "denom", Required: true}
+
+	var w jwriter.Writer
+	EncodeField(&w, &field)
+	data, err := w.BuildBytes()
+	if err != nil {
+		t.Fatalf("EncodeField() error: %v", err)
+	}
+	if string(data) != externalFieldString {
+		t.Errorf("EncodeField() = %s, want %s", data, externalFieldString)
+	}
+
+	var got typegen.Field
+	l := jlexer.Lexer{Data: data}
+	DecodeField(&l, &got)
+	if err := l.Error(); err != nil {
+		t.Fatalf("DecodeField() error: %v", err)
+	}
+	if got != field {
+		t.Errorf("DecodeField() = %+v, want %+v", got, field)
+	}
+}
+
+func TestExternalFields(t *testing.T) {
+	field := typegen.Field{Name: "Denom", Type: "string", JSONName: "denom", Required: true}
+	v := ExternalHolder{
+		Decl:   typegen.Decl{Name: "Coin", Fields: []typegen.Field{field}},
+		Fields: []typegen.Field{field},
+		Ptr:    &field,
+		ByName: map[string]typegen.Field{"denom": field},
+	}
+	want := `{"Decl":{"Name":"Coin","Doc":"","Fields":[` + externalFieldString + `],"Underlying":"","Consts":null},` +
+		`"Fields":[` + externalFieldString + `],"Ptr":` + externalFieldString + `,"ByName":{"denom":` + externalFieldString + `}}`
+
+	data, err := tinyjson.Marshal(&v)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var got ExternalHolder
+	if err := tinyjson.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if !reflect.DeepEqual(got, v) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, v)
+	}
+}
@@ -0,0 +1,57 @@
+package tests
+
+//tinyjson:json
+type WideStruct struct {
+	Address     string `json:"address"`
+	Admin       string `json:"admin"`
+	Balance     uint64 `json:"balance"`
+	Creator     string `json:"creator"`
+	CodeID      uint64 `json:"code_id"`
+	Label       string `json:"label"`
+	IbcPortID   string `json:"ibc_port_id"`
+	Extension   string `json:"extension"`
+	Height      uint64 `json:"height"`
+	Pinned      bool   `json:"pinned"`
+	Checksum    string `json:"checksum"`
+	Denom       string `json:"denom"`
+	Decimals    uint8  `json:"decimals"`
+	Symbol      string `json:"symbol"`
+	Name        string `json:"name"`
+	Owner       string `json:"owner"`
+	Minter      string `json:"minter"`
+	TotalSupply uint64 `json:"total_supply,string"`
+	Cap         uint64 `json:"cap,string"`
+	Marketing   string `json:"marketing"`
+	Ignored     string `json:"-"`
+	Unicode     string `json:"ünï"`
+}
+
+var wideStructValue = WideStruct{
+	Address:     "wasm1vdhkuarjv93hgttpv3j8yetnwvknqvpsxyrd7ruf",
+	Admin:       "admin",
+	Balance:     1000,
+	Creator:     "creator",
+	CodeID:      42,
+	Label:       "my token",
+	IbcPortID:   "wasm.port",
+	Extension:   "ext",
+	Height:      123456,
+	Pinned:      true,
+	Checksum:    "deadbeef",
+	Denom:       "utoken",
+	Decimals:    6,
+	Symbol:      "TKN",
+	Name:        "Token",
+	Owner:       "owner",
+	Minter:      "minter",
+	TotalSupply: 1000000,
+	Cap:         2000000,
+	Marketing:   "marketing",
+	Unicode:     "ü",
+}
+
+var wideStructString = `{"address":"wasm1vdhkuarjv93hgttpv3j8yetnwvknqvpsxyrd7ruf","admin":"admin",` +
+	`"balance":1000,"creator":"creator","code_id":42,"label":"my token","ibc_port_id":"wasm.port",` +
+	`"extension":"ext","height":123456,"pinned":true,"checksum":"deadbeef","denom":"utoken",` +
+	`"decimals":6,"symbol":"TKN","name":"Token","owner":"owner","minter":"minter",` +
+	`"total_supply":"1000000","cap":"2000000","marketing":"marketing","ünï":"ü"}`
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+func TestWideStruct(t *testing.T) {
+	data, err := tinyjson.Marshal(wideStructValue)
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() error: %v", err)
+	}
+	if string(data) != wideStructString {
+		t.Errorf("tinyjson.Marshal() = %s; want %s", data, wideStructString)
+	}
+
+	var got WideStruct
+	if err := tinyjson.Unmarshal([]byte(wideStructString), &got); err != nil {
+		t.Fatalf("tinyjson.Unmarshal() error: %v", err)
+	}
+	if !reflect.DeepEqual(got, wideStructValue) {
+		t.Errorf("tinyjson.Unmarshal() = %+v; want %+v", got, wideStructValue)
+	}
+}
+
+func TestWideStructUnknownNames(t *testing.T) {
+	// Names sharing their length and discriminating byte with known ones, escaped names and
+	// names of ignored fields.
+	data := `{"adres":"x","owned":"x","Owner":"x","symbo1":"x","Ignored":"x","-":"x",` +
+		`"\u006fwner":"escaped","ünï":"é","name":"Token"}`
+	var got WideStruct
+	if err := tinyjson.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("tinyjson.Unmarshal() error: %v", err)
+	}
+	want := WideStruct{Owner: "escaped", Unicode: "é", Name: "Token"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tinyjson.Unmarshal() = %+v; want %+v", got, want)
+	}
+}
+
+func BenchmarkWideStructUnmarshal(b *testing.B) {
+	data := []byte(wideStructString)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		var v WideStruct
+		if err := tinyjson.Unmarshal(data, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
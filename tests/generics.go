@@ -0,0 +1,46 @@
+package tests
+
+import "github.com/CosmWasm/tinyjson"
+
+//tinyjson:json
+type ContractResult[T any] struct {
+	Ok  *T     `json:"ok,omitempty"`
+	Err string `json:"error,omitempty"`
+}
+
+//tinyjson:json
+type Paginated[T any] struct {
+	Items    []T               `json:"items"`
+	Next     *T                `json:"next,omitempty"`
+	Total    uint64            `json:"total,string"`
+	Previous ContractResult[T] `json:"previous"`
+	Nested   []Paginated[Coin] `json:"nested,omitempty"`
+	Funds    map[string]T      `json:"funds,omitempty"`
+	Sender   tinyjson.Addr     `json:"sender,omitempty"`
+}
+
+//tinyjson:json
+type Pair[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+//tinyjson:json
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount uint64 `json:"amount,string"`
+}
+
+var paginatedValue = Paginated[Coin]{
+	Items: []Coin{{Denom: "uatom", Amount: 10}, {Denom: "ujuno", Amount: 20}},
+	Next:  &Coin{Denom: "uosmo", Amount: 30},
+	Total: 3,
+	Previous: ContractResult[Coin]{
+		Err: "not found",
+	},
+	Funds: map[string]Coin{"a": {Denom: "uatom", Amount: 1}},
+}
+
+var paginatedString = `{"items":[{"denom":"uatom","amount":"10"},{"denom":"ujuno","amount":"20"}],` +
+	`"next":{"denom":"uosmo","amount":"30"},"total":"3","previous":{"error":"not found"},` +
+	`"funds":{"a":{"denom":"uatom","amount":"1"}}}`
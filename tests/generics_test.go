@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+func TestGenericMarshal(t *testing.T) {
+	data, err := tinyjson.Marshal(paginatedValue)
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() error: %v", err)
+	}
+	if string(data) != paginatedString {
+		t.Errorf("tinyjson.Marshal() = %s; want %s", data, paginatedString)
+	}
+
+	var got Paginated[Coin]
+	if err := tinyjson.Unmarshal(data, &got); err != nil {
+		t.Fatalf("tinyjson.Unmarshal() error: %v", err)
+	}
+	if !reflect.DeepEqual(got, paginatedValue) {
+		t.Errorf("tinyjson.Unmarshal() = %+v; want %+v", got, paginatedValue)
+	}
+}
+
+func TestGenericPrimitives(t *testing.T) {
+	for _, test := range []struct {
+		v    tinyjson.MarshalerUnmarshaler
+		new  func() tinyjson.MarshalerUnmarshaler
+		json string
+	}{
+		{
+			v:    &Pair[string, uint64]{Key: "height", Value: 12345},
+			new:  func() tinyjson.MarshalerUnmarshaler { return &Pair[string, uint64]{} },
+			json: `{"key":"height","value":12345}`,
+		},
+		{
+			v:    &Pair[int8, bool]{Key: -1, Value: true},
+			new:  func() tinyjson.MarshalerUnmarshaler { return &Pair[int8, bool]{} },
+			json: `{"key":-1,"value":true}`,
+		},
+		{
+			v:    &Pair[tinyjson.Addr, []byte]{Key: "cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj", Value: []byte("hi")},
+			new:  func() tinyjson.MarshalerUnmarshaler { return &Pair[tinyjson.Addr, []byte]{} },
+			json: `{"key":"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj","value":"aGk="}`,
+		},
+		{
+			v:    &ContractResult[string]{Ok: new(string)},
+			new:  func() tinyjson.MarshalerUnmarshaler { return &ContractResult[string]{} },
+			json: `{"ok":""}`,
+		},
+		{
+			v:    &ContractResult[Paginated[uint32]]{Ok: &Paginated[uint32]{Items: []uint32{1, 2}, Total: 2}},
+			new:  func() tinyjson.MarshalerUnmarshaler { return &ContractResult[Paginated[uint32]]{} },
+			json: `{"ok":{"items":[1,2],"total":"2","previous":{}}}`,
+		},
+	} {
+		data, err := tinyjson.Marshal(test.v)
+		if err != nil {
+			t.Errorf("tinyjson.Marshal(%T) error: %v", test.v, err)
+			continue
+		}
+		if string(data) != test.json {
+			t.Errorf("tinyjson.Marshal(%T) = %s; want %s", test.v, data, test.json)
+		}
+
+		got := test.new()
+		if err := tinyjson.Unmarshal([]byte(test.json), got); err != nil {
+			t.Errorf("tinyjson.Unmarshal(%T) error: %v", got, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.v) {
+			t.Errorf("tinyjson.Unmarshal(%T) = %+v; want %+v", got, got, test.v)
+		}
+	}
+}
+
+func TestGenericUnsupported(t *testing.T) {
+	_, err := tinyjson.Marshal(Pair[string, float64]{Key: "pi", Value: 3.14})
+	if !errors.Is(err, tinyjson.ErrUnsupportedTypeParam) {
+		t.Errorf("tinyjson.Marshal() error = %v; want %v", err, tinyjson.ErrUnsupportedTypeParam)
+	}
+
+	var v Pair[string, float64]
+	err = tinyjson.Unmarshal([]byte(`{"key":"pi","value":3.14}`), &v)
+	if !errors.Is(err, tinyjson.ErrUnsupportedTypeParam) {
+		t.Errorf("tinyjson.Unmarshal() error = %v; want %v", err, tinyjson.ErrUnsupportedTypeParam)
+	}
+	if v.Key != "pi" {
+		t.Errorf("tinyjson.Unmarshal() key = %q; want %q", v.Key, "pi")
+	}
+}
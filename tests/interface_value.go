@@ -0,0 +1,8 @@
+package tests
+
+//tinyjson:json
+type InterfaceValueStruct struct {
+	Kind   string      `json:"kind"`
+	Params interface{} `json:"params"`
+	Extra  interface{} `json:"extra,omitempty"`
+}
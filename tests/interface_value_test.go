@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+func TestInterfaceValue(t *testing.T) {
+	data := `{"kind":"swap","params":{"offer":{"denom":"uatom","amount":18446744073709551616},"min":1.50,"route":[2,1]}}`
+
+	var v InterfaceValueStruct
+	if err := tinyjson.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatal(err)
+	}
+	params, ok := v.Params.(tinyjson.Value)
+	if !ok {
+		t.Fatalf("Params = %#v; want a tinyjson.Value", v.Params)
+	}
+	if offer, ok := params.Lookup("offer", "denom"); !ok || offer.String() != `"uatom"` {
+		t.Errorf(`Lookup("offer", "denom") = %v, %v`, offer, ok)
+	}
+
+	got, err := tinyjson.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != data {
+		t.Errorf("Marshal() = %s; want %s", got, data)
+	}
+}
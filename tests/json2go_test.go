@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+func TestJSON2GoRoundTrip(t *testing.T) {
+	for _, data := range []string{
+		`{"delegation_responses":[{"delegation":{"delegator_address":"cosmos1r5v5srda7xfth3hn2s26txvrcrntldjumt8mhl","validator_address":"cosmosvaloper1clpqr4nrk4khgkxj78fcwwh6dl3uw4epsluffn","shares":"250.500000000000000000"},"balance":{"denom":"uatom","amount":"18446744073709551615"},"rewards":[{"denom":"uatom","amount":"12.5"}]}],"pagination":{"next_key":"FPiz1ZwnFQOSlQa0kyKUu+sRcjqp","total":"2"},"height":19283746}`,
+		`{"delegation_responses":[],"pagination":{"total":"0"}}`,
+	} {
+		var v DelegationsResponse
+		if err := tinyjson.Unmarshal([]byte(data), &v); err != nil {
+			t.Fatalf("Unmarshal() error: %v", err)
+		}
+		out, err := tinyjson.Marshal(&v)
+		if err != nil {
+			t.Fatalf("Marshal() error: %v", err)
+		}
+		if string(out) != data {
+			t.Errorf("Marshal() = %s, want %s", out, data)
+		}
+	}
+}
+
+func TestJSON2GoTypes(t *testing.T) {
+	var v DelegationsResponse
+	data := `{"delegation_responses":[{"balance":{"denom":"uatom","amount":"1000000"}}],"pagination":{"next_key":null,"total":"1"}}`
+	if err := tinyjson.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if len(v.DelegationResponses) != 1 || v.DelegationResponses[0].Balance.Amount != 1000000 {
+		t.Errorf("DelegationResponses = %+v", v.DelegationResponses)
+	}
+	if v.Pagination.NextKey != nil || v.Pagination.Total != 1 || v.Height != 0 {
+		t.Errorf("Pagination = %+v, Height = %d", v.Pagination, v.Height)
+	}
+}
@@ -0,0 +1,35 @@
+package tests
+
+//tinyjson:json
+type MergeTarget struct {
+	Name   string            `json:"name"`
+	Count  int               `json:"count"`
+	Tags   []string          `json:"tags"`
+	Attrs  map[string]string `json:"attrs"`
+	Inner  *MergeInner       `json:"inner"`
+	Scores [3]int            `json:"scores"`
+}
+
+//tinyjson:json
+type MergeInner struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+//tinyjson:json
+type MergeMap map[string]int
+
+func mergeTargetValue() MergeTarget {
+	tags := make([]string, 2, 8)
+	tags[0], tags[1] = "x", "y"
+	return MergeTarget{
+		Name:   "old",
+		Count:  7,
+		Tags:   tags,
+		Attrs:  map[string]string{"k1": "v1", "k2": "v2"},
+		Inner:  &MergeInner{A: 1, B: 2},
+		Scores: [3]int{1, 2, 3},
+	}
+}
+
+var mergeTargetString = `{"name":"new","tags":["z"],"attrs":{"k2":"w2","k3":"w3"},"inner":{"a":10},"scores":[9],"count":null}`
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+func TestMergeOnDecode(t *testing.T) {
+	v := mergeTargetValue()
+	tags := v.Tags
+	if err := tinyjson.Unmarshal([]byte(mergeTargetString), &v); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+
+	want := MergeTarget{
+		Name:   "new",
+		Count:  7,
+		Tags:   []string{"z"},
+		Attrs:  map[string]string{"k1": "v1", "k2": "w2", "k3": "w3"},
+		Inner:  &MergeInner{A: 10, B: 2},
+		Scores: [3]int{9, 2, 3},
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", v, want)
+	}
+	if &v.Tags[0] != &tags[0] {
+		t.Errorf("Unmarshal() did not reuse the slice capacity")
+	}
+}
+
+func TestResetOnDecode(t *testing.T) {
+	v := mergeTargetValue()
+	tags := v.Tags
+	if err := tinyjson.UnmarshalReset([]byte(mergeTargetString), &v); err != nil {
+		t.Fatalf("UnmarshalReset() error: %v", err)
+	}
+
+	want := MergeTarget{
+		Name:   "new",
+		Tags:   []string{"z"},
+		Attrs:  map[string]string{"k2": "w2", "k3": "w3"},
+		Inner:  &MergeInner{A: 10},
+		Scores: [3]int{9},
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("UnmarshalReset() = %+v, want %+v", v, want)
+	}
+	if &v.Tags[0] != &tags[0] {
+		t.Errorf("UnmarshalReset() did not reuse the slice capacity")
+	}
+
+	v = mergeTargetValue()
+	if err := tinyjson.UnmarshalReset([]byte(`{"name":"new"}`), &v); err != nil {
+		t.Fatalf("UnmarshalReset() error: %v", err)
+	}
+	if want := (MergeTarget{Name: "new"}); !reflect.DeepEqual(v, want) {
+		t.Errorf("UnmarshalReset() = %+v, want %+v", v, want)
+	}
+}
+
+func TestResetOnDecodeMap(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		unmarshal func([]byte, tinyjson.Unmarshaler) error
+		want      MergeMap
+	}{
+		{name: "merge", unmarshal: tinyjson.Unmarshal, want: MergeMap{"a": 1, "b": 3, "c": 4}},
+		{name: "reset", unmarshal: tinyjson.UnmarshalReset, want: MergeMap{"b": 3, "c": 4}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			v := MergeMap{"a": 1, "b": 2}
+			if err := test.unmarshal([]byte(`{"b":3,"c":4}`), &v); err != nil {
+				t.Fatalf("unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(v, test.want) {
+				t.Errorf("got %v, want %v", v, test.want)
+			}
+		})
+	}
+}
+
+func TestResetOnDecodeOption(t *testing.T) {
+	tags := make([]string, 1, 4)
+	v := ResetTarget{
+		Name:  "old",
+		Count: 7,
+		Tags:  tags,
+		Attrs: map[string]string{"k1": "v1"},
+	}
+	if err := tinyjson.Unmarshal([]byte(`{"tags":["a","b"],"attrs":{"k2":"v2"}}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+
+	want := ResetTarget{
+		Tags:  []string{"a", "b"},
+		Attrs: map[string]string{"k2": "v2"},
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", v, want)
+	}
+	if &v.Tags[0] != &tags[0] {
+		t.Errorf("Unmarshal() did not reuse the slice capacity")
+	}
+}
@@ -0,0 +1,32 @@
+package tests
+
+//tinyjson:json
+type MetadataMsg struct {
+	Sender  string            `json:"sender,required"`
+	Amount  uint64            `json:"amount,string"`
+	Memo    string            `json:"memo,omitempty"`
+	Funds   []MetadataCoin    `json:"funds"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Parent  *MetadataMsg      `json:"parent,omitempty"`
+	Ignored int               `json:"-"`
+	MetadataEmbedded
+}
+
+type MetadataEmbedded struct {
+	Height int64 `json:"height"`
+}
+
+//tinyjson:json
+type MetadataCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+//tinyjson:json
+type MetadataCoins []MetadataCoin
+
+//tinyjson:json
+type MetadataPage[T any] struct {
+	Items []T           `json:"items"`
+	Total MetadataCoins `json:"total"`
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+)
+
+func TestMetadata(t *testing.T) {
+	info := tinyjson.TypeInfoOf(MetadataMsg{})
+	if info == nil {
+		t.Fatal("TypeInfoOf() = nil")
+	}
+	if info.Package != "github.com/CosmWasm/tinyjson/tests" || info.Name != "MetadataMsg" || info.Kind != tinyjson.KindStruct {
+		t.Errorf("TypeInfoOf() = %v.%v (%v)", info.Package, info.Name, info.Kind)
+	}
+
+	var names []string
+	for _, f := range info.Fields {
+		names = append(names, f.JSONName)
+	}
+	wantNames := []string{"sender", "amount", "memo", "funds", "labels", "parent", "height"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("field names = %v, want %v", names, wantNames)
+	}
+
+	for _, test := range []struct {
+		jsonName string
+		want     tinyjson.FieldInfo
+	}{
+		{"sender", tinyjson.FieldInfo{Name: "Sender", JSONName: "sender", Type: "string", Required: true}},
+		{"amount", tinyjson.FieldInfo{Name: "Amount", JSONName: "amount", Type: "uint64", AsString: true}},
+		{"memo", tinyjson.FieldInfo{Name: "Memo", JSONName: "memo", Type: "string", OmitEmpty: true}},
+		{"funds", tinyjson.FieldInfo{Name: "Funds", JSONName: "funds", Type: "[]MetadataCoin", Elem: MetadataCoin{}}},
+		{"parent", tinyjson.FieldInfo{Name: "Parent", JSONName: "parent", Type: "*MetadataMsg", OmitEmpty: true, Elem: MetadataMsg{}}},
+		{"height", tinyjson.FieldInfo{Name: "Height", JSONName: "height", Type: "int64"}},
+	} {
+		if f := info.Field(test.jsonName); f == nil || !reflect.DeepEqual(*f, test.want) {
+			t.Errorf("Field(%q) = %+v, want %+v", test.jsonName, f, test.want)
+		}
+	}
+	if f := info.Field("Ignored"); f != nil {
+		t.Errorf("Field(%q) = %+v, want nil", "Ignored", f)
+	}
+
+	if coin := info.Field("funds").Elem.TinyJSONTypeInfo(); coin.Name != "MetadataCoin" || len(coin.Fields) != 2 {
+		t.Errorf("funds element metadata = %+v", coin)
+	}
+	if parent := info.Field("parent").Elem.TinyJSONTypeInfo(); parent != info {
+		t.Errorf("parent metadata = %+v, want %+v", parent, info)
+	}
+}
+
+func TestMetadataNonStruct(t *testing.T) {
+	info := MetadataCoins{}.TinyJSONTypeInfo()
+	if info.Kind != tinyjson.KindSlice || info.Fields != nil {
+		t.Errorf("TinyJSONTypeInfo() = %+v", info)
+	}
+	if info.Elem == nil || info.Elem.TinyJSONTypeInfo() != (MetadataCoin{}).TinyJSONTypeInfo() {
+		t.Errorf("element metadata = %v", info.Elem)
+	}
+}
+
+func TestMetadataGeneric(t *testing.T) {
+	info := MetadataPage[MetadataCoin]{}.TinyJSONTypeInfo()
+	if info != (MetadataPage[string]{}).TinyJSONTypeInfo() {
+		t.Errorf("instantiations have different metadata")
+	}
+	if info.Name != "MetadataPage[T]" {
+		t.Errorf("Name = %q, want %q", info.Name, "MetadataPage[T]")
+	}
+	want := tinyjson.FieldInfo{Name: "Items", JSONName: "items", Type: "[]T"}
+	if f := info.Field("items"); f == nil || !reflect.DeepEqual(*f, want) {
+		t.Errorf("Field(%q) = %+v, want %+v", "items", f, want)
+	}
+}
+
+func TestMetadataRegistry(t *testing.T) {
+	const pkg = "github.com/CosmWasm/tinyjson/tests"
+	for _, name := range []string{"MetadataMsg", "MetadataCoin", "MetadataCoins", "MetadataPage[T]"} {
+		if info := tinyjson.LookupTypeInfo(pkg, name); info == nil || info.Name != name {
+			t.Errorf("LookupTypeInfo(%q) = %+v", name, info)
+		}
+	}
+	if info := tinyjson.LookupTypeInfo(pkg, "MetadataEmbedded"); info != nil {
+		t.Errorf("LookupTypeInfo(%q) = %+v, want nil", "MetadataEmbedded", info)
+	}
+
+	var names []string
+	for _, info := range tinyjson.TypeInfos() {
+		names = append(names, info.Name)
+	}
+	want := []string{"MetadataCoin", "MetadataCoins", "MetadataMsg", "MetadataPage[T]"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("TypeInfos() = %v, want %v", names, want)
+	}
+}
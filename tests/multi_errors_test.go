@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/jlexer"
+)
+
+func TestUnmarshalAllErrors(t *testing.T) {
+	data := `{
+  "error_struct": {"int": "1", "string": 2, "slice": [1, "x", 3]},
+  "int": 5000000000000000000000
+}`
+	var v ErrorNestedStruct
+	err := tinyjson.UnmarshalAllErrors([]byte(data), &v)
+
+	var merr *jlexer.MultiError
+	if !errors.As(err, &merr) {
+		t.Fatalf("UnmarshalAllErrors() error = %#v; want *jlexer.MultiError", err)
+	}
+	want := []struct {
+		Path string
+		Kind error
+	}{
+		{"$.error_struct.int", tinyjson.ErrTypeMismatch},
+		{"$.error_struct.string", tinyjson.ErrTypeMismatch},
+		{"$.error_struct.slice[1]", tinyjson.ErrTypeMismatch},
+		{"$.int", tinyjson.ErrOverflow},
+	}
+	if len(merr.Errors) != len(want) {
+		t.Fatalf("UnmarshalAllErrors() = %v; want %d errors", err, len(want))
+	}
+	for i, e := range merr.Errors {
+		if e.Path != want[i].Path || !errors.Is(e, want[i].Kind) {
+			t.Errorf("error %d = %s %v; want %s %v", i, e.Path, e.Err, want[i].Path, want[i].Kind)
+		}
+	}
+	if v.ErrorStruct.Slice[2] != 3 {
+		t.Errorf("decoding did not continue past the errors: %+v", v)
+	}
+	if !errors.Is(err, tinyjson.ErrOverflow) {
+		t.Errorf("errors.Is(%v, ErrOverflow) = false", err)
+	}
+}
+
+func TestUnmarshalAllErrorsFields(t *testing.T) {
+	var v DisallowUnknown
+	err := tinyjson.UnmarshalAllErrors([]byte(`{"a": {"b": 1}, "field_one": 1, "c": [2]}`), &v)
+	want := "3 errors:\n" +
+		"\t$.a: unknown field (line 1, column 7)\n" +
+		"\t$.field_one: expected string (line 1, column 30)\n" +
+		"\t$.c: unknown field (line 1, column 38)"
+	if err == nil || err.Error() != want {
+		t.Errorf("UnmarshalAllErrors() error = %v; want %v", err, want)
+	}
+
+	var r RequiredOptionalStruct
+	err = tinyjson.UnmarshalAllErrors([]byte(`{"first_name": 1}`), &r)
+	if !errors.Is(err, tinyjson.ErrTypeMismatch) || errors.Is(err, tinyjson.ErrRequiredField) {
+		t.Errorf("UnmarshalAllErrors() error = %v; want only a type mismatch", err)
+	}
+
+	if err := tinyjson.UnmarshalAllErrors([]byte(`{"first_name": "a"}`), &r); err != nil {
+		t.Errorf("UnmarshalAllErrors() error = %v; want nil", err)
+	}
+}
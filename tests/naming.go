@@ -0,0 +1,31 @@
+package tests
+
+// NamingKebab is generated with a custom field namer producing kebab-case names.
+//
+//tinyjson:json
+type NamingKebab struct {
+	HTTPStatus int
+	TotalCount int
+	Tagged     int `json:"tagged_field"`
+}
+
+//tinyjson:json screaming_snake_case
+type NamingScreaming struct {
+	HTTPStatus int
+	TotalCount int
+}
+
+//tinyjson:json pascal_case
+type NamingPascal struct {
+	HTTPStatus int
+	TotalCount int
+}
+
+var namingKebabValue = NamingKebab{HTTPStatus: 1, TotalCount: 2, Tagged: 3}
+var namingKebabString = `{"http-status":1,"total":2,"tagged_field":3}`
+
+var namingScreamingValue = NamingScreaming{HTTPStatus: 1, TotalCount: 2}
+var namingScreamingString = `{"HTTP_STATUS":1,"total":2}`
+
+var namingPascalValue = NamingPascal{HTTPStatus: 1, TotalCount: 2}
+var namingPascalString = `{"HttpStatus":1,"count":2}`
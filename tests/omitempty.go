@@ -10,3 +10,12 @@ type OmitEmptyDefault struct {
 
 var omitEmptyDefaultValue = OmitEmptyDefault{Field: "test"}
 var omitEmptyDefaultString = `{"Field":"test","s":"","Str2":""}`
+
+//tinyjson:json no_omit_empty
+type OmitEmptyOverride struct {
+	Field string
+	Str   string `json:",omitempty"`
+}
+
+var omitEmptyOverrideValue = OmitEmptyOverride{Field: "test"}
+var omitEmptyOverrideString = `{"Field":"test"}`
@@ -0,0 +1,72 @@
+package tests
+
+// ProtoKind mimics an enum generated by protoc-gen-gogo.
+type ProtoKind int32
+
+const (
+	ProtoKind_KIND_UNSPECIFIED ProtoKind = 0
+	ProtoKind_KIND_TRANSFER    ProtoKind = 1
+	ProtoKind_KIND_DELEGATE    ProtoKind = 2
+)
+
+var ProtoKind_name = map[int32]string{
+	0: "KIND_UNSPECIFIED",
+	1: "KIND_TRANSFER",
+	2: "KIND_DELEGATE",
+}
+
+var ProtoKind_value = map[string]int32{
+	"KIND_UNSPECIFIED": 0,
+	"KIND_TRANSFER":    1,
+	"KIND_DELEGATE":    2,
+}
+
+func (x ProtoKind) String() string {
+	return ProtoKind_name[int32(x)]
+}
+
+// Timestamp, Duration and Any have the shape of the generated well-known types.
+type Timestamp struct {
+	Seconds int64 `protobuf:"varint,1,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	Nanos   int32 `protobuf:"varint,2,opt,name=nanos,proto3" json:"nanos,omitempty"`
+}
+
+type Duration struct {
+	Seconds int64 `protobuf:"varint,1,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	Nanos   int32 `protobuf:"varint,2,opt,name=nanos,proto3" json:"nanos,omitempty"`
+}
+
+type Any struct {
+	TypeUrl string `protobuf:"bytes,1,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	Value   []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+//tinyjson:json
+type ProtoEvent struct {
+	EventId   uint64      `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Height    int64       `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Count     uint32      `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Kind      ProtoKind   `protobuf:"varint,4,opt,name=kind,proto3,enum=tests.ProtoKind" json:"kind,omitempty"`
+	Kinds     []ProtoKind `protobuf:"varint,5,rep,packed,name=kinds,proto3,enum=tests.ProtoKind" json:"kinds,omitempty"`
+	CreatedAt *Timestamp  `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Timeout   *Duration   `protobuf:"bytes,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Payload   *Any        `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	Memo      string      `protobuf:"bytes,9,opt,name=memo,proto3" json:"memo,omitempty"`
+	RawData   []byte      `protobuf:"bytes,10,opt,name=raw_data,json=rawData,proto3" json:"raw_data,omitempty"`
+}
+
+var protoEventValue = ProtoEvent{
+	EventId:   18446744073709551615,
+	Height:    -42,
+	Count:     7,
+	Kind:      ProtoKind_KIND_TRANSFER,
+	Kinds:     []ProtoKind{ProtoKind_KIND_DELEGATE, ProtoKind_KIND_UNSPECIFIED},
+	CreatedAt: &Timestamp{Seconds: 1700000000, Nanos: 120000000},
+	Timeout:   &Duration{Seconds: -3, Nanos: -500},
+	Payload:   &Any{TypeUrl: "/tests.ProtoCoin", Value: []byte("uatom")},
+	RawData:   []byte{1, 2, 3},
+}
+
+var protoEventString = `{"eventId":"18446744073709551615","height":"-42","count":7,"kind":"KIND_TRANSFER",` +
+	`"kinds":["KIND_DELEGATE","KIND_UNSPECIFIED"],"createdAt":"2023-11-14T22:13:20.120Z",` +
+	`"timeout":"-3.000000500s","payload":{"@type":"/tests.ProtoCoin","denom":"uatom"},"rawData":"AQID"}`
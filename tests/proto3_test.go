@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// protoCoinCodec encodes a coin message holding only a denom, the protobuf encoding being
+// replaced by the raw denom for simplicity.
+type protoCoinCodec struct{}
+
+func (protoCoinCodec) MarshalAnyJSON(w *jwriter.Writer, value []byte) {
+	w.RawString(`{"denom":`)
+	w.String(string(value))
+	w.RawByte('}')
+}
+
+func (protoCoinCodec) UnmarshalAnyJSON(l *jlexer.Lexer) []byte {
+	var denom string
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		if key == "denom" {
+			denom = l.String()
+		} else {
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+	return []byte(denom)
+}
+
+func init() {
+	tinyjson.RegisterProtoAny("/tests.ProtoCoin", protoCoinCodec{})
+}
+
+func TestProto3Marshal(t *testing.T) {
+	data, err := tinyjson.Marshal(protoEventValue)
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() error: %v", err)
+	}
+	if string(data) != protoEventString {
+		t.Errorf("tinyjson.Marshal() = %s; want %s", data, protoEventString)
+	}
+
+	data, err = tinyjson.Marshal(ProtoEvent{})
+	if err != nil {
+		t.Fatalf("tinyjson.Marshal() error: %v", err)
+	}
+	if string(data) != "{}" {
+		t.Errorf("tinyjson.Marshal() = %s; want {}", data)
+	}
+}
+
+func TestProto3Unmarshal(t *testing.T) {
+	for i, test := range []struct {
+		Data string
+		Want ProtoEvent
+	}{
+		{Data: protoEventString, Want: protoEventValue},
+		{
+			Data: `{"event_id":18446744073709551615,"height":"-42","count":"7","kind":2,"kinds":[1,"KIND_DELEGATE"]}`,
+			Want: ProtoEvent{
+				EventId: 18446744073709551615,
+				Height:  -42,
+				Count:   7,
+				Kind:    ProtoKind_KIND_DELEGATE,
+				Kinds:   []ProtoKind{ProtoKind_KIND_TRANSFER, ProtoKind_KIND_DELEGATE},
+			},
+		},
+		{
+			Data: `{"created_at":"2023-11-14T23:13:20.12+01:00","timeout":"1.5s","raw_data":"AQID"}`,
+			Want: ProtoEvent{
+				CreatedAt: &Timestamp{Seconds: 1700000000, Nanos: 120000000},
+				Timeout:   &Duration{Seconds: 1, Nanos: 500000000},
+				RawData:   []byte{1, 2, 3},
+			},
+		},
+		{
+			Data: `{"payload":{"denom":"uosmo","@type":"/tests.ProtoCoin"},"memo":null}`,
+			Want: ProtoEvent{Payload: &Any{TypeUrl: "/tests.ProtoCoin", Value: []byte("uosmo")}},
+		},
+	} {
+		var got ProtoEvent
+		if err := tinyjson.Unmarshal([]byte(test.Data), &got); err != nil {
+			t.Errorf("[%d] tinyjson.Unmarshal() error: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.Want) {
+			t.Errorf("[%d] tinyjson.Unmarshal() = %+v; want %+v", i, got, test.Want)
+		}
+	}
+}
+
+func TestProto3UnmarshalErrors(t *testing.T) {
+	for i, data := range []string{
+		`{"kind":"KIND_UNKNOWN"}`,
+		`{"createdAt":"2023-11-14 22:13:20Z"}`,
+		`{"timeout":"15"}`,
+		`{"createdAt":"0000-01-01T00:00:00Z"}`,
+		`{"timeout":"315576000001s"}`,
+		`{"payload":{"@type":"/tests.Unknown"}}`,
+	} {
+		var got ProtoEvent
+		if err := tinyjson.Unmarshal([]byte(data), &got); err == nil {
+			t.Errorf("[%d] tinyjson.Unmarshal(%s) expected error", i, data)
+		}
+	}
+}
+
+func TestProto3MarshalOutOfRange(t *testing.T) {
+	for _, test := range []struct {
+		v    ProtoEvent
+		want error
+	}{
+		{ProtoEvent{CreatedAt: &Timestamp{Seconds: -62135596801}}, jwriter.ErrTimestampRange},
+		{ProtoEvent{CreatedAt: &Timestamp{Seconds: 253402300800}}, jwriter.ErrTimestampRange},
+		{ProtoEvent{CreatedAt: &Timestamp{Nanos: -1}}, jwriter.ErrTimestampRange},
+		{ProtoEvent{Timeout: &Duration{Seconds: 315576000001}}, jwriter.ErrDurationRange},
+		{ProtoEvent{Timeout: &Duration{Seconds: 1, Nanos: -1}}, jwriter.ErrDurationRange},
+	} {
+		if _, err := tinyjson.Marshal(test.v); !errors.Is(err, test.want) {
+			t.Errorf("tinyjson.Marshal(%+v) error = %v; want %v", test.v, err, test.want)
+		}
+	}
+}
@@ -1,16 +1,20 @@
 package tests
 
 import (
-	"fmt"
 	"reflect"
 	"testing"
+
+	"github.com/CosmWasm/tinyjson/jlexer"
 )
 
 func TestRequiredField(t *testing.T) {
-	cases := []struct{ json, errorMessage string }{
-		{`{"first_name":"Foo", "last_name": "Bar"}`, ""},
-		{`{"last_name":"Bar"}`, "key 'first_name' is required"},
-		{"{}", "key 'first_name' is required"},
+	cases := []struct {
+		json, errorMessage string
+		offset             int
+	}{
+		{`{"first_name":"Foo", "last_name": "Bar"}`, "", 0},
+		{`{"last_name":"Bar"}`, "key 'first_name' is required", 19},
+		{"{}", "key 'first_name' is required", 2},
 	}
 
 	for _, tc := range cases {
@@ -21,8 +25,9 @@ func TestRequiredField(t *testing.T) {
 				t.Errorf("%s. UnmarshalJSON didn`t expect error: %v", tc.json, err)
 			}
 		} else {
-			if fmt.Sprintf("%v", err) != tc.errorMessage {
-				t.Errorf("%s. UnmarshalJSON expected error: %v. got: %v", tc.json, tc.errorMessage, err)
+			lerr, ok := err.(*jlexer.LexerError)
+			if !ok || lerr.Reason != tc.errorMessage || lerr.Offset != tc.offset {
+				t.Errorf("%s. UnmarshalJSON expected error: %v at offset %d. got: %v", tc.json, tc.errorMessage, tc.offset, err)
 			}
 		}
 	}
@@ -0,0 +1,9 @@
+package tests
+
+//tinyjson:json
+type ResetTarget struct {
+	Name  string            `json:"name"`
+	Count int               `json:"count"`
+	Tags  []string          `json:"tags"`
+	Attrs map[string]string `json:"attrs"`
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/jlexer"
+)
+
+func TestSchema2GoRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		v    interface {
+			tinyjson.Marshaler
+			tinyjson.Unmarshaler
+		}
+		data string
+	}{
+		{
+			name: "send",
+			v:    &ExecuteMsg{},
+			data: `{"send":{"amount":"100","contract":"wasm18vd8fpwxzck93qlwghaj6arh4p7c5n89k7fvsl","msg":"eyJzdGFrZSI6e319"}}`,
+		},
+		{
+			name: "expiration",
+			v:    &ExecuteMsg{},
+			data: `{"increase_allowance":{"amount":"5","expires":{"at_time":"1629131191823419000"},"spender":"bob"}}`,
+		},
+		{
+			name: "unit struct variant",
+			v:    &ExecuteMsg{},
+			data: `{"pause":{}}`,
+		},
+		{
+			name: "enum in enum",
+			v:    &ExecuteMsg{},
+			data: `{"upload_logo":{"embedded":{"png":"iVBORw0K"}}}`,
+		},
+		{
+			name: "optional fields",
+			v:    &QueryMsg{},
+			data: `{"all_accounts":{"limit":10}}`,
+		},
+		{
+			name: "instantiate",
+			v:    &InstantiateMsg{},
+			data: `{"decimals":6,"initial_balances":[{"address":"alice","amount":"1000"}],"mint":{"cap":"5000","minter":"alice"},"name":"Token","symbol":"TKN"}`,
+		},
+		{
+			name: "response",
+			v:    &StatusResponse{},
+			data: `{"admin":"wasm18vd8fpwxzck93qlwghaj6arh4p7c5n89k7fvsl","holders":{"alice":"1000"},"last_update":"1629131191823419000","recent":[["alice","12"]],"state":"paused"}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := tinyjson.Unmarshal([]byte(test.data), test.v); err != nil {
+				t.Fatalf("Unmarshal() error: %v", err)
+			}
+			data, err := tinyjson.Marshal(test.v)
+			if err != nil {
+				t.Fatalf("Marshal() error: %v", err)
+			}
+			if string(data) != test.data {
+				t.Errorf("Marshal() = %s, want %s", data, test.data)
+			}
+		})
+	}
+}
+
+func TestSchema2GoTypes(t *testing.T) {
+	var msg ExecuteMsg
+	data := `{"increase_allowance":{"amount":"5","expires":{"at_height":12345},"spender":"bob"}}`
+	if err := tinyjson.Unmarshal([]byte(data), &msg); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if msg.IncreaseAllowance == nil || msg.IncreaseAllowance.Expires == nil || msg.IncreaseAllowance.Expires.AtHeight == nil {
+		t.Fatalf("Unmarshal() = %+v", msg)
+	}
+	if h := *msg.IncreaseAllowance.Expires.AtHeight; h != 12345 {
+		t.Errorf("AtHeight = %d, want 12345", h)
+	}
+
+	var status StatusResponse
+	data = `{"admin":"wasm18vd8fpwxzck93qlwghaj6arh4p7c5n89k7fvsl","holders":{},"last_update":"1000000000","state":"active"}`
+	if err := tinyjson.Unmarshal([]byte(data), &status); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if status.State != StateActive || status.LastUpdate.Seconds() != 1 {
+		t.Errorf("Unmarshal() = %+v", status)
+	}
+}
+
+func TestSchema2GoRequired(t *testing.T) {
+	var msg InstantiateMsg
+	err := tinyjson.Unmarshal([]byte(`{"decimals":6,"initial_balances":[],"name":"Token"}`), &msg)
+	if !errors.Is(err, jlexer.ErrRequiredField) {
+		t.Errorf("Unmarshal() error = %v, want %v", err, jlexer.ErrRequiredField)
+	}
+}
@@ -8,3 +8,11 @@ type SnakeStruct struct {
 
 var snakeStructValue SnakeStruct
 var snakeStructString = `{"weird_http_stuff":false,"cUsToM":""}`
+
+//tinyjson:json go_case
+type SnakeOverrideStruct struct {
+	WeirdHTTPStuff bool
+}
+
+var snakeOverrideStructValue SnakeOverrideStruct
+var snakeOverrideStructString = `{"WeirdHTTPStuff":false}`
@@ -0,0 +1,25 @@
+package tests
+
+//tinyjson:json snake_case disallow_unknown
+type TypeOptionsSnake struct {
+	SomeValue  int
+	HTTPStatus string
+	Inner      TypeOptionsDefault
+}
+
+//tinyjson:json lower_camel_case omit_empty
+type TypeOptionsCamel struct {
+	SomeValue int
+	Inner     TypeOptionsDefault
+}
+
+//tinyjson:json
+type TypeOptionsDefault struct {
+	SomeValue int
+}
+
+var typeOptionsSnakeValue = TypeOptionsSnake{SomeValue: 1, Inner: TypeOptionsDefault{SomeValue: 2}}
+var typeOptionsSnakeString = `{"some_value":1,"http_status":"","inner":{"SomeValue":2}}`
+
+var typeOptionsCamelValue = TypeOptionsCamel{SomeValue: 1}
+var typeOptionsCamelString = `{"someValue":1,"inner":{"SomeValue":0}}`
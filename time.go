@@ -0,0 +1,206 @@
+package tinyjson
+
+import (
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// Timestamp is a point in time in nanoseconds since the Unix epoch, matching the Timestamp type
+// of cosmwasm-std: it is encoded as a string holding the number of nanoseconds. It does not use
+// the time package, so that it works under TinyGo.
+type Timestamp struct {
+	nanos uint64
+}
+
+// TimestampFromNanos returns the timestamp nanos nanoseconds after the Unix epoch.
+func TimestampFromNanos(nanos uint64) Timestamp {
+	return Timestamp{nanos: nanos}
+}
+
+// TimestampFromSeconds returns the timestamp seconds seconds after the Unix epoch.
+func TimestampFromSeconds(seconds uint64) Timestamp {
+	return Timestamp{}.Plus(DurationFromSeconds(seconds))
+}
+
+// Nanos returns the number of nanoseconds since the Unix epoch.
+func (t Timestamp) Nanos() uint64 {
+	return t.nanos
+}
+
+// Seconds returns the number of whole seconds since the Unix epoch.
+func (t Timestamp) Seconds() uint64 {
+	return t.nanos / 1e9
+}
+
+// SubsecNanos returns the nanoseconds elapsed since the last whole second.
+func (t Timestamp) SubsecNanos() uint64 {
+	return t.nanos % 1e9
+}
+
+// Plus returns the timestamp d after t. It panics on overflow.
+func (t Timestamp) Plus(d Duration) Timestamp {
+	if t.nanos+d.nanos < t.nanos {
+		panic("tinyjson: timestamp overflow")
+	}
+	return Timestamp{nanos: t.nanos + d.nanos}
+}
+
+// Minus returns the timestamp d before t. It panics if the result is before the Unix epoch.
+func (t Timestamp) Minus(d Duration) Timestamp {
+	if d.nanos > t.nanos {
+		panic("tinyjson: timestamp underflow")
+	}
+	return Timestamp{nanos: t.nanos - d.nanos}
+}
+
+// Sub returns the duration t-u. It panics if u is after t.
+func (t Timestamp) Sub(u Timestamp) Duration {
+	if u.nanos > t.nanos {
+		panic("tinyjson: negative duration")
+	}
+	return Duration{nanos: t.nanos - u.nanos}
+}
+
+// Compare returns -1, 0 or +1 depending on whether t is before, equal to or after u.
+func (t Timestamp) Compare(u Timestamp) int {
+	return compareUint64(t.nanos, u.nanos)
+}
+
+// Before reports whether t is before u.
+func (t Timestamp) Before(u Timestamp) bool {
+	return t.nanos < u.nanos
+}
+
+// After reports whether t is after u.
+func (t Timestamp) After(u Timestamp) bool {
+	return t.nanos > u.nanos
+}
+
+// AppendRFC3339 appends t formatted as an RFC 3339 UTC timestamp, e.g.
+// 2021-08-16T16:26:31.823419Z, to dst.
+func (t Timestamp) AppendRFC3339(dst []byte) []byte {
+	return jwriter.AppendRFC3339(dst, int64(t.Seconds()), int32(t.SubsecNanos()))
+}
+
+// RFC3339 returns t formatted as an RFC 3339 UTC timestamp.
+func (t Timestamp) RFC3339() string {
+	return string(t.AppendRFC3339(nil))
+}
+
+// String returns t formatted as an RFC 3339 UTC timestamp.
+func (t Timestamp) String() string {
+	return t.RFC3339()
+}
+
+// MarshalTinyJSON does JSON marshaling using tinyjson interface.
+func (t Timestamp) MarshalTinyJSON(w *jwriter.Writer) {
+	w.Uint64Str(t.nanos)
+}
+
+// UnmarshalTinyJSON does JSON unmarshaling using tinyjson interface.
+func (t *Timestamp) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	t.nanos = l.Uint64Str()
+}
+
+// MarshalJSON implements encoding/json.Marshaler interface.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	t.MarshalTinyJSON(&w)
+	return w.BuildBytes()
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler interface.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	t.UnmarshalTinyJSON(&l)
+	return l.Error()
+}
+
+// Duration is a non-negative span of time in nanoseconds, encoded like Timestamp as a string
+// holding the number of nanoseconds.
+type Duration struct {
+	nanos uint64
+}
+
+// DurationFromNanos returns the duration of nanos nanoseconds.
+func DurationFromNanos(nanos uint64) Duration {
+	return Duration{nanos: nanos}
+}
+
+// DurationFromSeconds returns the duration of seconds seconds. It panics on overflow.
+func DurationFromSeconds(seconds uint64) Duration {
+	if seconds > ^uint64(0)/1e9 {
+		panic("tinyjson: duration overflow")
+	}
+	return Duration{nanos: seconds * 1e9}
+}
+
+// Nanos returns the duration in nanoseconds.
+func (d Duration) Nanos() uint64 {
+	return d.nanos
+}
+
+// Seconds returns the duration in whole seconds.
+func (d Duration) Seconds() uint64 {
+	return d.nanos / 1e9
+}
+
+// Plus returns the duration d+e. It panics on overflow.
+func (d Duration) Plus(e Duration) Duration {
+	if d.nanos+e.nanos < d.nanos {
+		panic("tinyjson: duration overflow")
+	}
+	return Duration{nanos: d.nanos + e.nanos}
+}
+
+// Minus returns the duration d-e. It panics if e is longer than d.
+func (d Duration) Minus(e Duration) Duration {
+	if e.nanos > d.nanos {
+		panic("tinyjson: negative duration")
+	}
+	return Duration{nanos: d.nanos - e.nanos}
+}
+
+// Compare returns -1, 0 or +1 depending on whether d is shorter than, equal to or longer than e.
+func (d Duration) Compare(e Duration) int {
+	return compareUint64(d.nanos, e.nanos)
+}
+
+// String returns the duration in seconds with an 's' suffix, e.g. 1.5s.
+func (d Duration) String() string {
+	return string(jwriter.AppendDuration(nil, int64(d.Seconds()), int32(d.nanos%1e9)))
+}
+
+// MarshalTinyJSON does JSON marshaling using tinyjson interface.
+func (d Duration) MarshalTinyJSON(w *jwriter.Writer) {
+	w.Uint64Str(d.nanos)
+}
+
+// UnmarshalTinyJSON does JSON unmarshaling using tinyjson interface.
+func (d *Duration) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	d.nanos = l.Uint64Str()
+}
+
+// MarshalJSON implements encoding/json.Marshaler interface.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	d.MarshalTinyJSON(&w)
+	return w.BuildBytes()
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler interface.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	d.UnmarshalTinyJSON(&l)
+	return l.Error()
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,60 @@
+package tinyjson
+
+import (
+	"testing"
+)
+
+func TestTimestampJSON(t *testing.T) {
+	ts := TimestampFromNanos(1629131191823419000)
+	data, err := ts.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error: %v", err)
+	}
+	if string(data) != `"1629131191823419000"` {
+		t.Errorf("MarshalJSON() = %s; want %s", data, `"1629131191823419000"`)
+	}
+
+	var got Timestamp
+	if err := got.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON() error: %v", err)
+	}
+	if got != ts {
+		t.Errorf("UnmarshalJSON() = %v; want %v", got, ts)
+	}
+
+	for _, data := range []string{`1629131191823419000`, `"-1"`, `"1.5"`, `"18446744073709551616"`} {
+		if err := got.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) expected error", data)
+		}
+	}
+}
+
+func TestTimestampArithmetic(t *testing.T) {
+	ts := TimestampFromSeconds(1629131191)
+	later := ts.Plus(DurationFromNanos(1500000000))
+
+	if later.Seconds() != 1629131192 || later.SubsecNanos() != 500000000 {
+		t.Errorf("Plus() = %d.%09d; want 1629131192.500000000", later.Seconds(), later.SubsecNanos())
+	}
+	if !ts.Before(later) || !later.After(ts) || ts.Compare(later) != -1 || later.Compare(later) != 0 {
+		t.Errorf("comparison of %v and %v is wrong", ts, later)
+	}
+	if d := later.Sub(ts); d.Nanos() != 1500000000 || d.String() != "1.500s" {
+		t.Errorf("Sub() = %v; want 1.500s", d)
+	}
+	if got := later.Minus(DurationFromSeconds(2)); got != TimestampFromNanos(1629131190500000000) {
+		t.Errorf("Minus() = %d; want 1629131190500000000", got.Nanos())
+	}
+	if got := later.RFC3339(); got != "2021-08-16T16:26:32.500Z" {
+		t.Errorf("RFC3339() = %s; want 2021-08-16T16:26:32.500Z", got)
+	}
+}
+
+func TestTimestampOverflow(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Plus() did not panic on overflow")
+		}
+	}()
+	TimestampFromNanos(^uint64(0)).Plus(DurationFromNanos(1))
+}
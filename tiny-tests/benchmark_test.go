@@ -1,7 +1,11 @@
 package tinytest
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
 )
 
 func BenchmarkStd_Unmarshal_Env(b *testing.B) {
@@ -23,3 +27,49 @@ func BenchmarkStd_Unmarshal_Info(b *testing.B) {
 		}
 	}
 }
+
+var (
+	benchPlainString   = strings.Repeat("cosmwasm contract message ", 40)
+	benchEscapedString = strings.Repeat("line \"quoted\"\n<tag> & ", 40)
+)
+
+func benchmarkWriterString(b *testing.B, s string) {
+	b.SetBytes(int64(len(s)))
+	w := jwriter.Writer{}
+	for i := 0; i < b.N; i++ {
+		w.String(s)
+		w.Buffer.Buf = w.Buffer.Buf[:0]
+	}
+}
+
+func BenchmarkWriter_String_Plain(b *testing.B) {
+	benchmarkWriterString(b, benchPlainString)
+}
+
+func BenchmarkWriter_String_Escaped(b *testing.B) {
+	benchmarkWriterString(b, benchEscapedString)
+}
+
+// benchmarkLexerString measures reading s. With the gc compiler, the end of strings is found by
+// bytes.IndexByte unless the tinyjson_swar build tag forces the SWAR checks TinyGo uses.
+func benchmarkLexerString(b *testing.B, s string) {
+	w := jwriter.Writer{}
+	w.String(s)
+	data, _ := w.BuildBytes()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		l := jlexer.Lexer{Data: data}
+		l.UnsafeString()
+		if err := l.Error(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLexer_String_Plain(b *testing.B) {
+	benchmarkLexerString(b, benchPlainString)
+}
+
+func BenchmarkLexer_String_Escaped(b *testing.B) {
+	benchmarkLexerString(b, benchEscapedString)
+}
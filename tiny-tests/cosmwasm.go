@@ -1,5 +1,7 @@
 package tinytest
 
+import "github.com/CosmWasm/tinyjson"
+
 // TODO: investigate nocopy optimizations
 
 // basic, standard struct (with embedded structs)
@@ -10,16 +12,16 @@ type Env struct {
 
 type BlockInfo struct {
 	Height int64
-	Time   int64 `json:",string"`
+	Time   tinyjson.Timestamp
 }
 
 type ContractInfo struct {
-	Address string
+	Address tinyjson.Addr
 }
 
 // another important struct that includes a slice of structs (which caused issues with another parser)
 type MessageInfo struct {
-	Signer string
+	Signer tinyjson.Addr
 	// Note: added custom tag "emptyslice" to never encode to nil, but rather []
 	Funds []Coin `json:",emptyslice"`
 }
@@ -2,6 +2,9 @@ package tinytest
 
 import (
 	"testing"
+
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/jlexer"
 )
 
 // Encode and decode types
@@ -17,12 +20,12 @@ func TestEnvEncoding(t *testing.T) {
 			output:   `{"block":{"height":0,"time":"0"},"contract":{"address":""}}`,
 		},
 		"full": {
-			input: `{"contract":{"address":"my-contract"},"block":{"time":"1234567890","height":42}}`,
+			input: `{"contract":{"address":"wasm1vdhkuarjv93hgttpv3j8yetnwvknqvpsxyrd7ruf"},"block":{"time":"1234567890","height":42}}`,
 			expected: Env{
-				Contract: ContractInfo{Address: "my-contract"},
-				Block:    BlockInfo{Time: 1234567890, Height: 42},
+				Contract: ContractInfo{Address: "wasm1vdhkuarjv93hgttpv3j8yetnwvknqvpsxyrd7ruf"},
+				Block:    BlockInfo{Time: tinyjson.TimestampFromNanos(1234567890), Height: 42},
 			},
-			output: `{"block":{"height":42,"time":"1234567890"},"contract":{"address":"my-contract"}}`,
+			output: `{"block":{"height":42,"time":"1234567890"},"contract":{"address":"wasm1vdhkuarjv93hgttpv3j8yetnwvknqvpsxyrd7ruf"}}`,
 		},
 	}
 
@@ -62,17 +65,17 @@ func TestInfoEncoding(t *testing.T) {
 			output:   `{"signer":"","funds":[]}`,
 		},
 		"top fields": {
-			input: `{"signer":"cosmos1234","funds":[]}`,
+			input: `{"signer":"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj","funds":[]}`,
 			expected: MessageInfo{
-				Signer: "cosmos1234",
+				Signer: "cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj",
 				Funds:  []Coin{},
 			},
-			output: `{"signer":"cosmos1234","funds":[]}`,
+			output: `{"signer":"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj","funds":[]}`,
 		},
 		"multiple coins": {
-			input: `{"signer":"cosmos1234","funds":[{"amount":"12345","denom":"uatom"},{"amount":"76543","denom":"utgd"}]}`,
+			input: `{"signer":"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj","funds":[{"amount":"12345","denom":"uatom"},{"amount":"76543","denom":"utgd"}]}`,
 			expected: MessageInfo{
-				Signer: "cosmos1234",
+				Signer: "cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj",
 				Funds: []Coin{{
 					Amount: "12345",
 					Denom:  "uatom",
@@ -81,7 +84,7 @@ func TestInfoEncoding(t *testing.T) {
 					Denom:  "utgd",
 				}},
 			},
-			output: `{"signer":"cosmos1234","funds":[{"denom":"uatom","amount":"12345"},{"denom":"utgd","amount":"76543"}]}`,
+			output: `{"signer":"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj","funds":[{"denom":"uatom","amount":"12345"},{"denom":"utgd","amount":"76543"}]}`,
 		},
 	}
 
@@ -109,6 +112,42 @@ func TestInfoEncoding(t *testing.T) {
 	}
 }
 
+func TestAddrValidation(t *testing.T) {
+	cases := map[string]struct {
+		input  string
+		prefix string
+	}{
+		"bad checksum": {
+			input: `{"signer":"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsk"}`,
+		},
+		"uppercase": {
+			input: `{"signer":"COSMOS1WD5KWMN9WGKKZERYWFJHXUEDXQCRQVP3HDYCSJ"}`,
+		},
+		"not bech32": {
+			input: `{"signer":"my-contract"}`,
+		},
+		"wrong prefix": {
+			input:  `{"signer":"cosmos1wd5kwmn9wgkkzerywfjhxuedxqcrqvp3hdycsj"}`,
+			prefix: "wasm",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var loaded MessageInfo
+			l := jlexer.Lexer{Data: []byte(tc.input), AddrPrefix: tc.prefix}
+			loaded.UnmarshalTinyJSON(&l)
+			err := l.Error()
+			if err == nil {
+				t.Fatalf("Expected error, got %#v", loaded)
+			}
+			if lerr, ok := err.(*jlexer.LexerError); !ok || lerr.Offset != len(`{"signer":`) {
+				t.Fatalf("Unexpected error: %#v", err)
+			}
+		})
+	}
+}
+
 func TestMessageEncoding(t *testing.T) {
 	cases := map[string]struct {
 		input    string
@@ -3,12 +3,14 @@ package tinytest
 import (
 	"bytes"
 	"testing"
+
+	"github.com/CosmWasm/tinyjson"
 )
 
 var sampleEnv = Env{
 	Contract: ContractInfo{Address: "wasm18vd8fpwxzck93qlwghaj6arh4p7c5n89k7fvsl"},
 	// Time in nanoseconds since epoch start
-	Block: BlockInfo{Height: 78000, Time: 1629131191823419000},
+	Block: BlockInfo{Height: 78000, Time: tinyjson.TimestampFromNanos(1629131191823419000)},
 }
 
 var sampleEnvText = []byte(`{"block":{"height":78000,"time":"1629131191823419000"},"contract":{"address":"wasm18vd8fpwxzck93qlwghaj6arh4p7c5n89k7fvsl"}}`)
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CosmWasm/tinyjson/typegen"
+	"github.com/CosmWasm/tinyjson/typegen/sample"
+)
+
+// json2go implements the json2go command, writing the Go types inferred from sample JSON
+// documents.
+func json2go(args []string) error {
+	fs := flag.NewFlagSet("json2go", flag.ExitOnError)
+	name := fs.String("name", "", "name of the type of the documents, derived from the name of the first sample by default")
+	pkgName := fs.String("package", "", "package name of the output file, the name of its directory by default")
+	output := fs.String("output_filename", "", "output file, the name of the first sample with a .go extension by default")
+	typesOnly := fs.Bool("types_only", false, "only write the types, without generating their tinyjson codecs")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: tinyjson json2go [flags] sample.json...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	files := fs.Args()
+	if len(files) == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(files[0]), filepath.Ext(files[0]))
+	typeName := *name
+	if typeName == "" {
+		typeName = typegen.GoName(base)
+	}
+
+	in := sample.NewInferrer(typeName)
+	for _, fname := range files {
+		data, err := ioutil.ReadFile(fname)
+		if err != nil {
+			return err
+		}
+		if err := in.Add(data); err != nil {
+			return fmt.Errorf("%s: %v", fname, err)
+		}
+	}
+
+	outName := *output
+	if outName == "" {
+		outName = base + ".go"
+	}
+	return writeTypes(&typegen.File{
+		PkgName: *pkgName,
+		Command: "json2go",
+		Sources: files,
+		Decls:   in.Decls(),
+	}, outName, *typesOnly)
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -20,6 +22,13 @@ var buildTags = flag.String("build_tags", "", "build tags to add to generated fi
 var genBuildFlags = flag.String("gen_build_flags", "", "build flags when running the generator while bootstrapping")
 var snakeCase = flag.Bool("snake_case", false, "use snake_case names instead of CamelCase by default")
 var lowerCamelCase = flag.Bool("lower_camel_case", false, "use lowerCamelCase names instead of CamelCase by default")
+var kebabCase = flag.Bool("kebab_case", false, "use kebab-case names instead of CamelCase by default")
+var screamingSnakeCase = flag.Bool("screaming_snake_case", false, "use SCREAMING_SNAKE_CASE names instead of CamelCase by default")
+var pascalCase = flag.Bool("pascal_case", false, "use PascalCase names, with only the first letter of words uppercase, instead of CamelCase by default")
+var fieldNamer = flag.String("field_namer", "", "Go expression of a custom gen.FieldNamer, qualified with its import path, e.g. 'example.com/names.Namer{}'")
+var renameFile = flag.String("rename_file", "", "JSON file mapping field names, or type and field names (Type.Field), to member names")
+var codecFile = flag.String("codec_file", "", "JSON file mapping fully qualified type names to their encoder and decoder, function names or snippets of code")
+var externalTypes = flag.String("external", "", "comma-separated fully qualified names of types from other packages, e.g. example.com/bank.Coin, to generate standalone EncodeX/DecodeX functions for")
 var noStdMarshalers = flag.Bool("no_std_marshalers", false, "don't generate MarshalJSON/UnmarshalJSON funcs")
 var omitEmpty = flag.Bool("omit_empty", false, "omit empty fields by default")
 var allStructs = flag.Bool("all", false, "generate marshaler/unmarshalers for all structs in a file")
@@ -31,6 +40,27 @@ var specifiedName = flag.String("output_filename", "", "specify the filename of
 var processPkg = flag.Bool("pkg", false, "process the whole package instead of just the given file")
 var disallowUnknownFields = flag.Bool("disallow_unknown_fields", false, "return error if any unknown field in json appeared")
 var skipMemberNameUnescaping = flag.Bool("disable_members_unescape", false, "don't perform unescaping of member names to improve performance")
+var aminoJSON = flag.Bool("amino_json", false, "generate encoders producing Cosmos SDK legacy Amino JSON")
+var proto3JSON = flag.Bool("proto3_json", false, "generate marshaler/unmarshalers following the proto3 JSON mapping")
+var metadata = flag.Bool("metadata", false, "generate a static table describing the JSON fields of each type, available through tinyjson.Describer")
+var check = flag.Bool("check", false, "regenerate in memory and report the differences with the output file, failing if it is out of date, instead of writing it")
+var interfaceValue = flag.Bool("interface_value", false, "decode interface{} values into tinyjson.Value, keeping the order of object members, rather than map[string]interface{}")
+var genTests = flag.Bool("gen_tests", false, "also generate a _test.go file with table and fuzz tests comparing the encodings with encoding/json")
+var resetOnDecode = flag.Bool("reset_on_decode", false, "reset structs to their zero value before decoding into them instead of merging")
+
+// commands are the subcommands of tinyjson, given as first argument.
+var commands = map[string]func(args []string) error{
+	"schema2go": schema2go,
+	"json2go":   json2go,
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "Usage: tinyjson [flags] file.go...")
+	fmt.Fprintln(out, "       tinyjson schema2go [flags] schema.json...")
+	fmt.Fprintln(out, "       tinyjson json2go [flags] sample.json...")
+	flag.PrintDefaults()
+}
 
 func generate(fname string) (err error) {
 	fInfo, err := os.Stat(fname)
@@ -58,6 +88,35 @@ func generate(fname string) (err error) {
 		outName = *specifiedName
 	}
 
+	var renames map[string]string
+	if *renameFile != "" {
+		data, err := ioutil.ReadFile(*renameFile)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &renames); err != nil {
+			return fmt.Errorf("Error parsing %v: %v", *renameFile, err)
+		}
+	}
+
+	var codecs map[string]bootstrap.Codec
+	if *codecFile != "" {
+		data, err := ioutil.ReadFile(*codecFile)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &codecs); err != nil {
+			return fmt.Errorf("Error parsing %v: %v", *codecFile, err)
+		}
+	}
+
+	var external []string
+	if *externalTypes != "" {
+		for _, t := range strings.Split(*externalTypes, ",") {
+			external = append(external, strings.TrimSpace(t))
+		}
+	}
+
 	var trimmedBuildTags string
 	if *buildTags != "" {
 		trimmedBuildTags = strings.TrimSpace(*buildTags)
@@ -76,24 +135,55 @@ func generate(fname string) (err error) {
 		Types:                    p.StructNames,
 		SnakeCase:                *snakeCase,
 		LowerCamelCase:           *lowerCamelCase,
+		KebabCase:                *kebabCase,
+		ScreamingSnakeCase:       *screamingSnakeCase,
+		PascalCase:               *pascalCase,
+		FieldNamer:               *fieldNamer,
+		Renames:                  renames,
+		Codecs:                   codecs,
+		External:                 external,
 		NoStdMarshalers:          *noStdMarshalers,
 		DisallowUnknownFields:    *disallowUnknownFields,
+		ResetOnDecode:            *resetOnDecode,
+		InterfaceValue:           *interfaceValue,
+		Metadata:                 *metadata,
 		SkipMemberNameUnescaping: *skipMemberNameUnescaping,
+		AminoJSON:                *aminoJSON,
+		AminoNames:               p.AminoNames,
+		TypeOptions:              p.TypeOptions,
+		TypeParams:               p.TypeParams,
+		Proto3JSON:               *proto3JSON,
 		OmitEmpty:                *omitEmpty,
 		LeaveTemps:               *leaveTemps,
 		OutName:                  outName,
 		StubsOnly:                *stubs,
+		Check:                    *check,
+		GenTests:                 *genTests,
 		NoFormat:                 *noformat,
 		SimpleBytes:              *simpleBytes,
 	}
 
 	if err := g.Run(); err != nil {
+		if errors.Is(err, bootstrap.ErrStale) {
+			return err
+		}
 		return fmt.Errorf("Bootstrap failed: %v", err)
 	}
 	return nil
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if cmd := commands[os.Args[1]]; cmd != nil {
+			if err := cmd(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	flag.Usage = usage
 	flag.Parse()
 
 	files := flag.Args()
@@ -110,10 +200,19 @@ func main() {
 		os.Exit(1)
 	}
 
+	// In check mode, all the files are checked before failing if any is out of date.
+	stale := false
 	for _, fname := range files {
-		if err := generate(fname); err != nil {
+		err := generate(fname)
+		if errors.Is(err, bootstrap.ErrStale) {
+			fmt.Fprintln(os.Stderr, err)
+			stale = true
+		} else if err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
 	}
+	if stale {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/CosmWasm/tinyjson/typegen"
+	"github.com/CosmWasm/tinyjson/typegen/schema"
+)
+
+// schema2go implements the schema2go command, writing the Go types described by the JSON
+// Schemas of a CosmWasm contract and generating their codecs.
+func schema2go(args []string) error {
+	fs := flag.NewFlagSet("schema2go", flag.ExitOnError)
+	pkgName := fs.String("package", "", "package name of the output file, the name of its directory by default")
+	output := fs.String("output_filename", "", "output file, the name of the first schema with a .go extension by default")
+	typesOnly := fs.Bool("types_only", false, "only write the types, without generating their tinyjson codecs")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: tinyjson schema2go [flags] schema.json...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	files := fs.Args()
+	if len(files) == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	c := schema.NewConverter()
+	for _, fname := range files {
+		data, err := ioutil.ReadFile(fname)
+		if err != nil {
+			return err
+		}
+		schemas, err := schema.Parse(data)
+		if err != nil {
+			return fmt.Errorf("%s: %v", fname, err)
+		}
+		for _, s := range schemas {
+			if err := c.Add(s); err != nil {
+				return fmt.Errorf("%s: %v", fname, err)
+			}
+		}
+	}
+
+	outName := *output
+	if outName == "" {
+		outName = strings.TrimSuffix(filepath.Base(files[0]), filepath.Ext(files[0])) + ".go"
+	}
+	return writeTypes(&typegen.File{
+		PkgName: *pkgName,
+		Command: "schema2go",
+		Sources: files,
+		Decls:   c.Decls(),
+	}, outName, *typesOnly)
+}
+
+// writeTypes writes the type declarations to outName and, unless typesOnly is set, generates
+// their codecs.
+func writeTypes(f *typegen.File, outName string, typesOnly bool) error {
+	if !strings.HasSuffix(outName, ".go") {
+		return errors.New("Filename must end in '.go'")
+	}
+	if f.PkgName == "" {
+		abs, err := filepath.Abs(outName)
+		if err != nil {
+			return err
+		}
+		f.PkgName = packageName(filepath.Base(filepath.Dir(abs)))
+	}
+
+	src, err := f.Source()
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(outName, src, 0644); err != nil {
+		return err
+	}
+	if typesOnly {
+		return nil
+	}
+	return generate(outName)
+}
+
+// packageName returns a valid package name for a directory name.
+func packageName(dir string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, dir)
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "types" + name
+	}
+	return name
+}
@@ -0,0 +1,273 @@
+// Package sample infers Go type declarations from sample JSON documents.
+package sample
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/CosmWasm/tinyjson/typegen"
+)
+
+// Kinds of JSON values observed at a position of the samples, a bit set.
+const (
+	kindNull = 1 << iota
+	kindBool
+	kindInt
+	kindUint // An integer beyond the range of int64.
+	kindFloat
+	kindString
+	kindIntString  // A string holding an integer in the range of int64.
+	kindUintString // A string holding an integer beyond the range of int64 in the range of uint64.
+	kindObject
+	kindArray
+)
+
+// shape is what was observed at a position of the samples, merged across samples.
+type shape struct {
+	kinds int
+
+	// objects is the number of objects observed, fields their members in order of appearance.
+	objects int
+	fields  []*field
+
+	elem *shape // Items of the arrays.
+}
+
+// field is an object member.
+type field struct {
+	name  string
+	count int // Number of objects the member was observed in.
+	shape *shape
+}
+
+// Inferrer infers the types of sample JSON documents.
+type Inferrer struct {
+	name string
+	root *shape
+
+	decls    []*typegen.Decl
+	declared map[string]bool
+}
+
+// NewInferrer returns an inferrer of the type with the given name, which is given to the
+// documents, nested objects being named after their parent type and member.
+func NewInferrer(name string) *Inferrer {
+	return &Inferrer{name: name, root: &shape{}}
+}
+
+// Add merges the documents in data, one or more JSON values, into the inferred types. The
+// documents must all be objects or all be arrays.
+func (in *Inferrer) Add(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	n := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		d, ok := tok.(json.Delim)
+		if !ok || (d != '{' && d != '[') {
+			return errors.New("sample is not an object or an array")
+		}
+		// The documents have a single type, a struct or a slice.
+		if d == '{' && in.root.kinds&kindArray != 0 {
+			return errors.New("sample is an object but previous samples are arrays")
+		}
+		if d == '[' && in.root.kinds&kindObject != 0 {
+			return errors.New("sample is an array but previous samples are objects")
+		}
+		if err := in.root.addValue(dec, tok); err != nil {
+			return err
+		}
+		n++
+	}
+	if n == 0 {
+		return errors.New("no JSON document")
+	}
+	return nil
+}
+
+// addValue merges the value starting with tok into the shape.
+func (s *shape) addValue(dec *json.Decoder, tok json.Token) error {
+	switch v := tok.(type) {
+	case nil:
+		s.kinds |= kindNull
+	case bool:
+		s.kinds |= kindBool
+	case json.Number:
+		s.kinds |= numberKind(string(v))
+	case string:
+		s.kinds |= stringKind(v)
+	case json.Delim:
+		if v == '[' {
+			s.kinds |= kindArray
+			if s.elem == nil {
+				s.elem = &shape{}
+			}
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if err := s.elem.addValue(dec, tok); err != nil {
+					return err
+				}
+			}
+		} else {
+			s.kinds |= kindObject
+			s.objects++
+			seen := map[string]bool{}
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				name := tok.(string)
+				if tok, err = dec.Token(); err != nil {
+					return err
+				}
+				f := s.field(name)
+				if !seen[name] {
+					seen[name] = true
+					f.count++
+				}
+				if err := f.shape.addValue(dec, tok); err != nil {
+					return err
+				}
+			}
+		}
+		_, err := dec.Token() // Closing delimiter.
+		return err
+	}
+	return nil
+}
+
+// field returns the member with the given name, adding it if it was not observed yet.
+func (s *shape) field(name string) *field {
+	for _, f := range s.fields {
+		if f.name == name {
+			return f
+		}
+	}
+	f := &field{name: name, shape: &shape{}}
+	s.fields = append(s.fields, f)
+	return f
+}
+
+// numberKind returns the kind of a JSON number.
+func numberKind(n string) int {
+	if _, err := strconv.ParseInt(n, 10, 64); err == nil {
+		return kindInt
+	}
+	if _, err := strconv.ParseUint(n, 10, 64); err == nil {
+		return kindUint
+	}
+	return kindFloat
+}
+
+// stringKind returns the kind of a JSON string, detecting integers encoded as strings.
+func stringKind(s string) int {
+	if len(s) > 1 && (s[0] == '0' || (s[0] == '-' && s[1] == '0')) {
+		// Leading zeros are not canonical, e.g. an identifier rather than a number.
+		return kindString
+	}
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return kindIntString
+	}
+	if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return kindUintString
+	}
+	return kindString
+}
+
+// Decls returns the declarations of the inferred types, the type of the documents first.
+func (in *Inferrer) Decls() []*typegen.Decl {
+	in.decls = nil
+	in.declared = map[string]bool{}
+
+	if in.root.kinds&kindObject != 0 {
+		in.declareStruct(in.name, in.root)
+	} else {
+		d := &typegen.Decl{Name: in.name}
+		in.declared[in.name] = true
+		in.decls = append(in.decls, d)
+		typ, _ := in.elemType(in.root.elem, in.name+"Item")
+		d.Underlying = "[]" + typ
+	}
+	return in.decls
+}
+
+// declareStruct declares a struct type for the objects of the shape.
+func (in *Inferrer) declareStruct(name string, s *shape) string {
+	name = typegen.UniqueName(name, func(n string) bool { return in.declared[n] })
+	in.declared[name] = true
+	d := &typegen.Decl{Name: name}
+	in.decls = append(in.decls, d)
+
+	names := map[string]bool{}
+	for _, f := range s.fields {
+		fname := typegen.UniqueName(typegen.GoName(f.name), func(n string) bool { return names[n] })
+		names[fname] = true
+
+		optional := f.count < s.objects || f.shape.kinds&kindNull != 0
+		typ, asString := in.goType(f.shape, name+fname)
+		if optional && f.shape.kinds&^kindNull == kindObject {
+			typ = "*" + typ
+		} else if f.shape.kinds&kindNull != 0 && !typegen.Nilable(typ) {
+			typ = "*" + typ
+		}
+		d.Fields = append(d.Fields, typegen.Field{
+			Name:      fname,
+			Type:      typ,
+			JSONName:  f.name,
+			OmitEmpty: optional,
+			AsString:  asString,
+		})
+	}
+	return name
+}
+
+// goType returns the Go type of the values of the shape, declaring the struct types it needs,
+// named after ctx, and whether it is an integer encoded as a string.
+func (in *Inferrer) goType(s *shape, ctx string) (string, bool) {
+	switch s.kinds &^ kindNull {
+	case kindBool:
+		return "bool", false
+	case kindInt:
+		return "int64", false
+	case kindUint, kindInt | kindUint:
+		return "uint64", false
+	case kindFloat, kindInt | kindFloat, kindUint | kindFloat, kindInt | kindUint | kindFloat:
+		return "float64", false
+	case kindIntString:
+		return "int64", true
+	case kindUintString, kindIntString | kindUintString:
+		return "uint64", true
+	case kindString, kindString | kindIntString, kindString | kindUintString, kindString | kindIntString | kindUintString:
+		return "string", false
+	case kindObject:
+		return in.declareStruct(ctx, s), false
+	case kindArray:
+		typ, asString := in.elemType(s.elem, ctx+"Item")
+		return "[]" + typ, asString
+	}
+	// Nothing but null, or values of different types.
+	return "tinyjson.RawMessage", false
+}
+
+// elemType returns the Go type of the items of arrays and whether it is an integer encoded as
+// a string.
+func (in *Inferrer) elemType(s *shape, ctx string) (string, bool) {
+	typ, asString := in.goType(s, ctx)
+	if s.kinds&kindNull != 0 && !typegen.Nilable(typ) {
+		typ = "*" + typ
+	}
+	return typ, asString
+}
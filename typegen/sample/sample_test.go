@@ -0,0 +1,125 @@
+package sample
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson/typegen"
+)
+
+func infer(t *testing.T, name string, samples ...string) []*typegen.Decl {
+	t.Helper()
+	in := NewInferrer(name)
+	for _, s := range samples {
+		if err := in.Add([]byte(s)); err != nil {
+			t.Fatalf("Add(%s) error: %v", s, err)
+		}
+	}
+	return in.Decls()
+}
+
+func TestInferFields(t *testing.T) {
+	decls := infer(t, "Msg",
+		`{"denom": "uatom", "amount": "100", "height": 10, "ratio": 1, "memo": null, "coin": {"denom": "uatom"}}`,
+		`{"denom": "uosmo", "amount": "18446744073709551615", "height": 11, "ratio": 0.5, "memo": "hi", "extra": true}`,
+	)
+
+	var names []string
+	for _, d := range decls {
+		names = append(names, d.Name)
+	}
+	if got := strings.Join(names, " "); got != "Msg MsgCoin" {
+		t.Errorf("declared types = %s, want Msg MsgCoin", got)
+	}
+
+	want := []typegen.Field{
+		{Name: "Denom", Type: "string", JSONName: "denom"},
+		{Name: "Amount", Type: "uint64", JSONName: "amount", AsString: true},
+		{Name: "Height", Type: "int64", JSONName: "height"},
+		{Name: "Ratio", Type: "float64", JSONName: "ratio"},
+		{Name: "Memo", Type: "*string", JSONName: "memo", OmitEmpty: true},
+		{Name: "Coin", Type: "*MsgCoin", JSONName: "coin", OmitEmpty: true},
+		{Name: "Extra", Type: "bool", JSONName: "extra", OmitEmpty: true},
+	}
+	if len(decls[0].Fields) != len(want) {
+		t.Fatalf("Msg fields = %+v, want %+v", decls[0].Fields, want)
+	}
+	for i, f := range decls[0].Fields {
+		if f != want[i] {
+			t.Errorf("Msg field %d = %+v, want %+v", i, f, want[i])
+		}
+	}
+}
+
+func TestInferTypes(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		values   []string
+		typ      string
+		asString bool
+	}{
+		{"int", []string{`1`, `-2`}, "int64", false},
+		{"uint", []string{`1`, `18446744073709551615`}, "uint64", false},
+		{"float", []string{`1`, `1.5`}, "float64", false},
+		{"int string", []string{`"-12"`, `"34"`}, "int64", true},
+		{"uint string", []string{`"12"`, `"18446744073709551615"`}, "uint64", true},
+		{"leading zeros", []string{`"0012"`}, "string", false},
+		{"numbers and strings", []string{`"12"`, `"abc"`}, "string", false},
+		{"numbers in strings and not", []string{`12`, `"12"`}, "tinyjson.RawMessage", false},
+		{"null", []string{`null`}, "tinyjson.RawMessage", false},
+		{"nullable array", []string{`null`, `[1]`}, "[]int64", false},
+		{"nullable items", []string{`["1", null]`}, "[]*int64", true},
+		{"nested arrays", []string{`[[true]]`}, "[][]bool", false},
+		{"mixed items", []string{`[1, "a"]`}, "[]tinyjson.RawMessage", false},
+		{"array of objects", []string{`[{"a": 1}]`, `[]`}, "[]MsgVItem", false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var samples []string
+			for _, v := range test.values {
+				samples = append(samples, `{"v": `+v+`}`)
+			}
+			f := infer(t, "Msg", samples...)[0].Fields[0]
+			if f.Type != test.typ || f.AsString != test.asString {
+				t.Errorf("type = %s, as string %v, want %s, as string %v", f.Type, f.AsString, test.typ, test.asString)
+			}
+		})
+	}
+}
+
+func TestInferDocuments(t *testing.T) {
+	// Newline-delimited documents, the top level being an array.
+	decls := infer(t, "Coins", `[{"denom": "uatom"}]`+"\n"+`[{"denom": "uosmo", "amount": "1"}]`)
+	if len(decls) != 2 || decls[0].Underlying != "[]CoinsItem" || decls[1].Name != "CoinsItem" {
+		t.Fatalf("declared types = %+v", decls)
+	}
+	if f := decls[1].Fields[1]; f.Type != "int64" || !f.OmitEmpty || !f.AsString {
+		t.Errorf("CoinsItem.amount = %+v", f)
+	}
+
+	// Objects named after the same member are declared once per position.
+	decls = infer(t, "Msg", `{"a": {"b": {"x": 1}}, "a_b": {"y": 2}}`)
+	var names []string
+	for _, d := range decls {
+		names = append(names, d.Name)
+	}
+	if got := strings.Join(names, " "); got != "Msg MsgA MsgAB MsgAB2" {
+		t.Errorf("declared types = %s, want Msg MsgA MsgAB MsgAB2", got)
+	}
+}
+
+func TestInferErrors(t *testing.T) {
+	for _, data := range []string{``, `"a"`, `{"a": }`, `[1`, `{"a": 1} [2]`, `[1] {"a": 2}`} {
+		if err := NewInferrer("Msg").Add([]byte(data)); err == nil {
+			t.Errorf("Add(%q) error = nil", data)
+		}
+	}
+
+	// Samples added separately cannot mix root kinds either.
+	in := NewInferrer("Msg")
+	if err := in.Add([]byte(`{"a": 1}`)); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if err := in.Add([]byte(`[{"a": 2}]`)); err == nil {
+		t.Errorf("Add() of an array after an object error = nil")
+	}
+}
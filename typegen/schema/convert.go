@@ -0,0 +1,377 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/CosmWasm/tinyjson/typegen"
+)
+
+// goType is the Go type a schema is converted to.
+type goType struct {
+	name     string
+	nullable bool // Whether the schema also accepts null.
+	asString bool // Whether the type is an integer encoded as a string.
+}
+
+// builtins maps the names of the definitions of cosmwasm-std types to the Go types they are
+// converted to.
+var builtins = map[string]goType{
+	"Addr":             {name: "tinyjson.Addr"},
+	"Binary":           {name: "tinyjson.Binary"},
+	"HexBinary":        {name: "tinyjson.HexBinary"},
+	"Timestamp":        {name: "tinyjson.Timestamp"},
+	"Uint64":           {name: "uint64", asString: true},
+	"Int64":            {name: "int64", asString: true},
+	"Uint128":          {name: "string"},
+	"Uint256":          {name: "string"},
+	"Uint512":          {name: "string"},
+	"Int128":           {name: "string"},
+	"Int256":           {name: "string"},
+	"Int512":           {name: "string"},
+	"Decimal":          {name: "string"},
+	"Decimal256":       {name: "string"},
+	"SignedDecimal":    {name: "string"},
+	"SignedDecimal256": {name: "string"},
+}
+
+// integerTypes maps the formats of integer schemas to Go types.
+var integerTypes = map[string]string{
+	"uint8":  "uint8",
+	"uint16": "uint16",
+	"uint32": "uint32",
+	"uint64": "uint64",
+	"uint":   "uint",
+	"int8":   "int8",
+	"int16":  "int16",
+	"int32":  "int32",
+	"int64":  "int64",
+	"int":    "int",
+}
+
+// Converter converts schemas to Go type declarations.
+type Converter struct {
+	roots []*typegen.Decl // Types of the converted schemas, in order.
+	decls []*typegen.Decl // Types of definitions and inline objects and enums.
+
+	declared map[string]*typegen.Decl
+
+	// definitions of the schema being converted
+	defs map[string]*Schema
+}
+
+// NewConverter returns a new converter.
+func NewConverter() *Converter {
+	return &Converter{declared: map[string]*typegen.Decl{}}
+}
+
+// Add converts a schema, named after its title, and the definitions it refers to. Definitions
+// are shared by all the schemas: one with the name of an already converted type is assumed to
+// describe the same type.
+func (c *Converter) Add(s *Schema) error {
+	if s.Title == "" {
+		return errors.New("schema has no title")
+	}
+	c.defs = s.Definitions
+	_, err := c.declare(typegen.GoName(s.Title), s, true)
+	return err
+}
+
+// Decls returns the declarations of the converted types: the types of the schemas first,
+// followed by the other types sorted by name.
+func (c *Converter) Decls() []*typegen.Decl {
+	decls := append([]*typegen.Decl{}, c.decls...)
+	sort.Slice(decls, func(i, j int) bool {
+		return decls[i].Name < decls[j].Name
+	})
+	return append(append([]*typegen.Decl{}, c.roots...), decls...)
+}
+
+// declare declares the type name described by s, unless it is already declared.
+func (c *Converter) declare(name string, s *Schema, root bool) (*typegen.Decl, error) {
+	if d := c.declared[name]; d != nil {
+		return d, nil
+	}
+	d := &typegen.Decl{Name: name, Doc: s.Description}
+	c.declared[name] = d
+	if root {
+		c.roots = append(c.roots, d)
+	} else {
+		c.decls = append(c.decls, d)
+	}
+
+	switch {
+	case isStringEnum(s):
+		d.Underlying = "string"
+		c.declareConsts(d, s)
+		return d, nil
+	case optional(s) != nil:
+	case len(s.OneOf) > 0:
+		return d, c.declareUnion(d, s.OneOf)
+	case len(s.AnyOf) > 0:
+		return d, c.declareUnion(d, s.AnyOf)
+	case isStruct(s):
+		return d, c.declareStruct(d, s)
+	}
+
+	t, err := c.goType(s, name)
+	if err != nil {
+		return nil, err
+	}
+	if t.nullable && !typegen.Nilable(t.name) {
+		t.name = "*" + t.name
+	}
+	d.Underlying = t.name
+	return d, nil
+}
+
+// declareStruct declares the fields of a struct for the properties of an object schema.
+func (c *Converter) declareStruct(d *typegen.Decl, s *Schema) error {
+	names := map[string]bool{}
+	for _, key := range sortedKeys(s.Properties) {
+		p := s.Properties[key]
+		name := typegen.UniqueName(typegen.GoName(key), func(n string) bool { return names[n] })
+		names[name] = true
+
+		t, err := c.goType(p, d.Name+name)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %v", d.Name, key, err)
+		}
+		required := s.isRequired(key)
+		if t.nullable && !typegen.Nilable(t.name) {
+			t.name = "*" + t.name
+		}
+		d.Fields = append(d.Fields, typegen.Field{
+			Name:      name,
+			Type:      t.name,
+			JSONName:  key,
+			Doc:       p.Description,
+			OmitEmpty: !required,
+			Required:  required && !t.nullable,
+			AsString:  t.asString,
+		})
+	}
+	return nil
+}
+
+// declareUnion declares the fields of a struct for the variants of an enum serialized the
+// way serde does by default: {"variant": value}. Only one of the fields is expected to be set.
+func (c *Converter) declareUnion(d *typegen.Decl, variants []*Schema) error {
+	names := map[string]bool{}
+	for _, v := range variants {
+		if isStringEnum(v) {
+			return fmt.Errorf("%s: unit variants %v, encoded as strings, cannot be mixed with variants holding values", d.Name, v.Enum)
+		}
+		if !isStruct(v) || len(v.Properties) != 1 {
+			return fmt.Errorf("%s: unsupported variant, expected an object with a single member", d.Name)
+		}
+		key := sortedKeys(v.Properties)[0]
+		name := typegen.UniqueName(typegen.GoName(key), func(n string) bool { return names[n] })
+		names[name] = true
+
+		t, err := c.goType(v.Properties[key], d.Name+name)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %v", d.Name, key, err)
+		}
+		if !typegen.Nilable(t.name) {
+			t.name = "*" + t.name
+		}
+		d.Fields = append(d.Fields, typegen.Field{
+			Name:      name,
+			Type:      t.name,
+			JSONName:  key,
+			Doc:       v.Description,
+			OmitEmpty: true,
+			AsString:  t.asString,
+		})
+	}
+	return nil
+}
+
+// declareConsts declares the constants of an enum of strings.
+func (c *Converter) declareConsts(d *typegen.Decl, s *Schema) {
+	for _, v := range s.Enum {
+		value := v.(string)
+		d.Consts = append(d.Consts, typegen.Const{Name: d.Name + typegen.GoName(value), Value: value})
+	}
+	for _, v := range s.OneOf {
+		doc := v.Description
+		for _, e := range v.Enum {
+			value := e.(string)
+			d.Consts = append(d.Consts, typegen.Const{Name: d.Name + typegen.GoName(value), Value: value, Doc: doc})
+			doc = ""
+		}
+	}
+}
+
+// goType returns the Go type of values described by s, declaring the named types it needs,
+// named after ctx for inline objects and enums.
+func (c *Converter) goType(s *Schema, ctx string) (goType, error) {
+	if s.Bool != nil {
+		return goType{name: "tinyjson.RawMessage"}, nil
+	}
+	if s.Ref != "" {
+		return c.refType(s.Ref)
+	}
+	if len(s.AllOf) == 1 {
+		return c.goType(s.AllOf[0], ctx)
+	}
+	if v := optional(s); v != nil {
+		t, err := c.goType(v, ctx)
+		t.nullable = true
+		return t, err
+	}
+	if isStringEnum(s) || len(s.OneOf) > 0 || len(s.AnyOf) > 0 || isStruct(s) {
+		d, err := c.declare(ctx, s, false)
+		if err != nil {
+			return goType{}, err
+		}
+		return goType{name: d.Name, nullable: s.hasType("null")}, nil
+	}
+
+	var types []string
+	for _, t := range s.Type {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	nullable := len(types) < len(s.Type)
+	if len(types) != 1 {
+		return goType{name: "tinyjson.RawMessage"}, nil
+	}
+
+	switch types[0] {
+	case "string":
+		return goType{name: "string", nullable: nullable}, nil
+	case "boolean":
+		return goType{name: "bool", nullable: nullable}, nil
+	case "number":
+		return goType{name: "float64", nullable: nullable}, nil
+	case "integer":
+		name := integerTypes[s.Format]
+		if name == "" {
+			name = "int64"
+		}
+		return goType{name: name, nullable: nullable}, nil
+	case "array":
+		elem, err := c.itemType(s, ctx+"Item")
+		if err != nil {
+			return goType{}, err
+		}
+		return goType{name: "[]" + elem.name, nullable: nullable, asString: elem.asString}, nil
+	case "object":
+		elem, err := c.goType(s.AdditionalProperties, ctx+"Value")
+		if err != nil {
+			return goType{}, err
+		}
+		if elem.nullable && !typegen.Nilable(elem.name) {
+			elem.name = "*" + elem.name
+		}
+		return goType{name: "map[string]" + elem.name, nullable: nullable, asString: elem.asString}, nil
+	}
+	return goType{name: "tinyjson.RawMessage"}, nil
+}
+
+// itemType returns the Go type of the items of an array schema. The items of tuples are raw
+// JSON values, unless they all have the same type.
+func (c *Converter) itemType(s *Schema, ctx string) (goType, error) {
+	if s.Items.All != nil {
+		t, err := c.goType(s.Items.All, ctx)
+		if t.nullable && !typegen.Nilable(t.name) {
+			t.name = "*" + t.name
+		}
+		return t, err
+	}
+
+	var item *goType
+	for _, is := range s.Items.Tuple {
+		t, err := c.goType(is, ctx)
+		if err != nil {
+			return goType{}, err
+		}
+		if item != nil && *item != t {
+			return goType{name: "tinyjson.RawMessage"}, nil
+		}
+		item = &t
+	}
+	if item == nil || item.nullable {
+		return goType{name: "tinyjson.RawMessage"}, nil
+	}
+	return *item, nil
+}
+
+// refType returns the Go type of a reference to a definition.
+func (c *Converter) refType(ref string) (goType, error) {
+	name := strings.TrimPrefix(ref, "#/definitions/")
+	if name == ref {
+		return goType{}, fmt.Errorf("unsupported reference %q", ref)
+	}
+	if t, ok := builtins[name]; ok {
+		return t, nil
+	}
+	def := c.defs[name]
+	if def == nil {
+		return goType{}, fmt.Errorf("undefined reference %q", ref)
+	}
+	d, err := c.declare(typegen.GoName(name), def, false)
+	if err != nil {
+		return goType{}, err
+	}
+	return goType{name: d.Name}, nil
+}
+
+// optional returns the schema of T if s describes an Option<T>, i.e. T or null, nil otherwise.
+func optional(s *Schema) *Schema {
+	for _, variants := range [][]*Schema{s.AnyOf, s.OneOf} {
+		if len(variants) != 2 {
+			continue
+		}
+		for i, v := range variants {
+			if len(v.Type) == 1 && v.Type[0] == "null" {
+				return variants[1-i]
+			}
+		}
+	}
+	return nil
+}
+
+// isStruct reports whether s describes an object with known members, converted to a struct.
+func isStruct(s *Schema) bool {
+	if !s.hasType("object") {
+		return false
+	}
+	return len(s.Properties) > 0 || s.AdditionalProperties == nil || s.AdditionalProperties.Bool != nil
+}
+
+// isStringEnum reports whether s describes an enum of strings, e.g. a Rust enum with unit
+// variants only.
+func isStringEnum(s *Schema) bool {
+	if len(s.Enum) > 0 {
+		for _, v := range s.Enum {
+			if _, ok := v.(string); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	if len(s.OneOf) == 0 {
+		return false
+	}
+	for _, v := range s.OneOf {
+		if len(v.Enum) == 0 || !isStringEnum(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,176 @@
+package schema
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/CosmWasm/tinyjson/typegen"
+)
+
+func convert(t *testing.T, data string) ([]*typegen.Decl, error) {
+	t.Helper()
+	schemas, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	c := NewConverter()
+	for _, s := range schemas {
+		if err := c.Add(s); err != nil {
+			return nil, err
+		}
+	}
+	return c.Decls(), nil
+}
+
+func field(d *typegen.Decl, jsonName string) *typegen.Field {
+	for i := range d.Fields {
+		if d.Fields[i].JSONName == jsonName {
+			return &d.Fields[i]
+		}
+	}
+	return nil
+}
+
+func TestConvertContract(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/cw20.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decls, err := convert(t, string(data))
+	if err != nil {
+		t.Fatalf("convert error: %v", err)
+	}
+
+	var names []string
+	byName := map[string]*typegen.Decl{}
+	for _, d := range decls {
+		names = append(names, d.Name)
+		byName[d.Name] = d
+	}
+	// Messages and responses come first, in order, followed by the other types by name.
+	want := "InstantiateMsg ExecuteMsg QueryMsg AllAccountsResponse BalanceResponse StatusResponse " +
+		"Cw20Coin EmbeddedLogo ExecuteMsgIncreaseAllowance ExecuteMsgPause ExecuteMsgSend " +
+		"ExecuteMsgTransfer Expiration ExpirationNever InstantiateMarketingInfo Logo MinterResponse " +
+		"QueryMsgAllAccounts QueryMsgBalance QueryMsgStatus State"
+	if got := strings.Join(names, " "); got != want {
+		t.Errorf("declared types = %s, want %s", got, want)
+	}
+
+	for _, test := range []struct {
+		typ, jsonName string
+		want          typegen.Field
+	}{
+		{"InstantiateMsg", "decimals", typegen.Field{Name: "Decimals", Type: "uint8", JSONName: "decimals", Required: true}},
+		{"InstantiateMsg", "mint", typegen.Field{Name: "Mint", Type: "*MinterResponse", JSONName: "mint", OmitEmpty: true}},
+		{"ExecuteMsg", "pause", typegen.Field{Name: "Pause", Type: "*ExecuteMsgPause", JSONName: "pause", OmitEmpty: true, Doc: "Pauses all transfers until resumed."}},
+		{"ExecuteMsgSend", "msg", typegen.Field{Name: "Msg", Type: "tinyjson.Binary", JSONName: "msg", Required: true}},
+		{"MinterResponse", "cap", typegen.Field{Name: "Cap", Type: "*string", JSONName: "cap", OmitEmpty: true, Doc: byName["MinterResponse"].Fields[0].Doc}},
+		{"Expiration", "at_time", typegen.Field{Name: "AtTime", Type: "*tinyjson.Timestamp", JSONName: "at_time", OmitEmpty: true, Doc: "AtTime will expire when `env.block.time` >= time"}},
+		{"EmbeddedLogo", "png", typegen.Field{Name: "Png", Type: "tinyjson.Binary", JSONName: "png", OmitEmpty: true, Doc: byName["EmbeddedLogo"].Fields[1].Doc}},
+		{"QueryMsgAllAccounts", "limit", typegen.Field{Name: "Limit", Type: "*uint32", JSONName: "limit", OmitEmpty: true}},
+		{"StatusResponse", "admin", typegen.Field{Name: "Admin", Type: "tinyjson.Addr", JSONName: "admin", Required: true}},
+		{"StatusResponse", "holders", typegen.Field{Name: "Holders", Type: "map[string]string", JSONName: "holders", Required: true}},
+		{"StatusResponse", "recent", typegen.Field{Name: "Recent", Type: "[][]tinyjson.RawMessage", JSONName: "recent", OmitEmpty: true}},
+		{"StatusResponse", "state", typegen.Field{Name: "State", Type: "State", JSONName: "state", Required: true}},
+	} {
+		d := byName[test.typ]
+		if d == nil {
+			t.Errorf("%s not declared", test.typ)
+			continue
+		}
+		if f := field(d, test.jsonName); f == nil || *f != test.want {
+			t.Errorf("%s.%s = %+v, want %+v", test.typ, test.jsonName, f, test.want)
+		}
+	}
+
+	state := byName["State"]
+	if state.Underlying != "string" || len(state.Consts) != 2 || state.Consts[1] != (typegen.Const{Name: "StatePaused", Value: "paused", Doc: "Transfers are paused by the admin."}) {
+		t.Errorf("State = %+v", state)
+	}
+}
+
+func TestConvertTypes(t *testing.T) {
+	decls, err := convert(t, `{
+		"title": "Msg",
+		"type": "object",
+		"required": ["height", "pairs", "raw"],
+		"properties": {
+			"height": {"$ref": "#/definitions/Uint64"},
+			"heights": {"type": "array", "items": {"$ref": "#/definitions/Uint64"}},
+			"pairs": {"type": "array", "items": {"type": "array", "items": [{"type": "string"}, {"type": "string"}]}},
+			"raw": true,
+			"kind": {"type": "string", "enum": ["a", "b-c"]},
+			"config": {"$ref": "#/definitions/Nullable_Config"}
+		},
+		"definitions": {
+			"Uint64": {"type": "string"},
+			"Nullable_Config": {"anyOf": [{"$ref": "#/definitions/Config"}, {"type": "null"}]},
+			"Config": {"type": "object", "properties": {"extra": {"type": "object", "additionalProperties": {"type": ["integer", "null"], "format": "int32"}}}}
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("convert error: %v", err)
+	}
+
+	msg := decls[0]
+	for _, want := range []typegen.Field{
+		{Name: "Config", Type: "NullableConfig", JSONName: "config", OmitEmpty: true},
+		{Name: "Height", Type: "uint64", JSONName: "height", Required: true, AsString: true},
+		{Name: "Heights", Type: "[]uint64", JSONName: "heights", OmitEmpty: true, AsString: true},
+		{Name: "Kind", Type: "MsgKind", JSONName: "kind", OmitEmpty: true},
+		{Name: "Pairs", Type: "[][]string", JSONName: "pairs", Required: true},
+		{Name: "Raw", Type: "tinyjson.RawMessage", JSONName: "raw", Required: true},
+	} {
+		if f := field(msg, want.JSONName); f == nil || *f != want {
+			t.Errorf("Msg.%s = %+v, want %+v", want.JSONName, f, want)
+		}
+	}
+
+	var got []string
+	for _, d := range decls[1:] {
+		got = append(got, d.Name+" "+d.Underlying)
+	}
+	want := []string{"Config ", "MsgKind string", "NullableConfig *Config"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("declared types = %q, want %q", got, want)
+	}
+	if f := decls[1].Fields[0]; f.Type != "map[string]*int32" {
+		t.Errorf("Config.extra type = %s, want map[string]*int32", f.Type)
+	}
+	if c := decls[2].Consts[1]; c.Name != "MsgKindBC" || c.Value != "b-c" {
+		t.Errorf("MsgKind const = %+v", c)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	for _, test := range []struct {
+		name, schema, err string
+	}{
+		{
+			name:   "no title",
+			schema: `{"type": "object"}`,
+			err:    "schema has no title",
+		},
+		{
+			name:   "undefined reference",
+			schema: `{"title": "Msg", "type": "object", "properties": {"a": {"$ref": "#/definitions/Missing"}}}`,
+			err:    `Msg.a: undefined reference "#/definitions/Missing"`,
+		},
+		{
+			name: "mixed variants",
+			schema: `{"title": "ExecuteMsg", "oneOf": [
+				{"type": "string", "enum": ["reset"]},
+				{"type": "object", "required": ["set"], "properties": {"set": {"type": "integer"}}}
+			]}`,
+			err: "ExecuteMsg: unit variants [reset], encoded as strings, cannot be mixed with variants holding values",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := convert(t, test.schema)
+			if err == nil || err.Error() != test.err {
+				t.Errorf("convert error = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
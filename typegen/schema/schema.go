@@ -0,0 +1,142 @@
+// Package schema converts the JSON Schemas published by CosmWasm contracts, as generated by
+// cosmwasm-schema and schemars, to Go type declarations.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Schema is the subset of JSON Schema draft 7 used by schemars.
+type Schema struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	Type   typeList      `json:"type"`
+	Format string        `json:"format"`
+	Ref    string        `json:"$ref"`
+	Enum   []interface{} `json:"enum"`
+
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+
+	Items    itemList `json:"items"`
+	MaxItems *int     `json:"maxItems"`
+
+	OneOf []*Schema `json:"oneOf"`
+	AnyOf []*Schema `json:"anyOf"`
+	AllOf []*Schema `json:"allOf"`
+
+	Definitions map[string]*Schema `json:"definitions"`
+
+	// Bool is set for the schemas true and false, which accept any value and no value.
+	Bool *bool `json:"-"`
+}
+
+// UnmarshalJSON decodes a schema, which may be a boolean.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && (data[0] == 't' || data[0] == 'f') {
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil {
+			return err
+		}
+		*s = Schema{Bool: &b}
+		return nil
+	}
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// hasType reports whether the schema accepts values of the JSON type t.
+func (s *Schema) hasType(t string) bool {
+	for _, t1 := range s.Type {
+		if t1 == t {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequired reports whether the object member name is required.
+func (s *Schema) isRequired(name string) bool {
+	for _, r := range s.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// typeList is the type keyword, a single type or a list of types.
+type typeList []string
+
+func (l *typeList) UnmarshalJSON(data []byte) error {
+	var t string
+	if err := json.Unmarshal(data, &t); err == nil {
+		*l = typeList{t}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// itemList is the items keyword, a schema for all items or a list of schemas for tuples.
+type itemList struct {
+	All   *Schema
+	Tuple []*Schema
+}
+
+func (l *itemList) UnmarshalJSON(data []byte) error {
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, &l.Tuple)
+	}
+	return json.Unmarshal(data, &l.All)
+}
+
+// contractSchema is the file written by cosmwasm-schema's write_api!, holding the schemas of
+// all the messages of a contract.
+type contractSchema struct {
+	ContractName string             `json:"contract_name"`
+	Instantiate  *Schema            `json:"instantiate"`
+	Execute      *Schema            `json:"execute"`
+	Query        *Schema            `json:"query"`
+	Migrate      *Schema            `json:"migrate"`
+	Sudo         *Schema            `json:"sudo"`
+	Responses    map[string]*Schema `json:"responses"`
+}
+
+// Parse returns the schemas in data, a single schema or a contract schema file written by
+// cosmwasm-schema listing the schemas of all messages.
+func Parse(data []byte) ([]*Schema, error) {
+	var probe struct {
+		ContractName *string `json:"contract_name"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+
+	if probe.ContractName == nil {
+		s := &Schema{}
+		if err := json.Unmarshal(data, s); err != nil {
+			return nil, fmt.Errorf("invalid schema: %v", err)
+		}
+		return []*Schema{s}, nil
+	}
+
+	var c contractSchema
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid contract schema: %v", err)
+	}
+	var schemas []*Schema
+	for _, s := range []*Schema{c.Instantiate, c.Execute, c.Query, c.Migrate, c.Sudo} {
+		if s != nil {
+			schemas = append(schemas, s)
+		}
+	}
+	for _, name := range sortedKeys(c.Responses) {
+		schemas = append(schemas, c.Responses[name])
+	}
+	return schemas, nil
+}
@@ -0,0 +1,201 @@
+// Package typegen writes Go type declarations, annotated for the tinyjson generator, from a
+// simple description of the types. It is shared by the commands inferring Go types from JSON
+// Schemas and from sample documents.
+package typegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const pkgTinyJSON = "github.com/CosmWasm/tinyjson"
+
+// File is a Go source file declaring types.
+type File struct {
+	PkgName string
+	Command string // Command the file is generated by, e.g. "schema2go".
+	Sources []string
+
+	Decls []*Decl
+}
+
+// Decl is a type declaration. Struct types have Fields, other types an Underlying type and
+// possibly Consts, e.g. for enums of strings.
+type Decl struct {
+	Name       string
+	Doc        string
+	Fields     []Field
+	Underlying string
+	Consts     []Const
+}
+
+// Field is a field of a struct type.
+type Field struct {
+	Name      string
+	Type      string
+	JSONName  string
+	Doc       string
+	OmitEmpty bool
+	Required  bool
+	AsString  bool
+}
+
+// Const is a constant of a declared type.
+type Const struct {
+	Name  string
+	Value string
+	Doc   string
+}
+
+// IsStruct reports whether the declared type is a struct type.
+func (d *Decl) IsStruct() bool {
+	return d.Underlying == ""
+}
+
+// Source returns the gofmt-ed source of the file. Struct types are annotated with a
+// tinyjson:json comment.
+func (f *File) Source() ([]byte, error) {
+	var body bytes.Buffer
+	for _, d := range f.Decls {
+		body.WriteString("\n")
+		writeDoc(&body, "", d.Doc)
+		if d.IsStruct() {
+			body.WriteString("//tinyjson:json\n")
+			if len(d.Fields) == 0 {
+				body.WriteString("type " + d.Name + " struct{}\n")
+				continue
+			}
+			body.WriteString("type " + d.Name + " struct {\n")
+			for _, fd := range d.Fields {
+				writeDoc(&body, "\t", fd.Doc)
+				body.WriteString("\t" + fd.Name + " " + fd.Type + " " + fd.tag() + "\n")
+			}
+			body.WriteString("}\n")
+		} else {
+			body.WriteString("type " + d.Name + " " + d.Underlying + "\n")
+		}
+		if len(d.Consts) > 0 {
+			body.WriteString("\nconst (\n")
+			for _, c := range d.Consts {
+				writeDoc(&body, "\t", c.Doc)
+				body.WriteString("\t" + c.Name + " " + d.Name + " = " + strconv.Quote(c.Value) + "\n")
+			}
+			body.WriteString(")\n")
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by tinyjson %s", f.Command)
+	if len(f.Sources) > 0 {
+		fmt.Fprintf(&b, " from %s", strings.Join(f.Sources, ", "))
+	}
+	b.WriteString(". DO NOT EDIT.\n\n")
+	b.WriteString("package " + f.PkgName + "\n")
+	if bytes.Contains(body.Bytes(), []byte("tinyjson.")) {
+		b.WriteString("\nimport " + strconv.Quote(pkgTinyJSON) + "\n")
+	}
+	b.Write(body.Bytes())
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %v", err)
+	}
+	return src, nil
+}
+
+// tag returns the struct tag of the field.
+func (f *Field) tag() string {
+	tag := f.JSONName
+	if f.OmitEmpty {
+		tag += ",omitempty"
+	}
+	if f.Required {
+		tag += ",required"
+	}
+	if f.AsString {
+		tag += ",string"
+	}
+	return "`json:" + strconv.Quote(tag) + "`"
+}
+
+// maxDocLine is the length comment lines are wrapped at.
+const maxDocLine = 100
+
+// writeDoc writes text as a comment, wrapping long lines.
+func writeDoc(b *bytes.Buffer, indent, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			b.WriteString(indent + "//\n")
+			continue
+		}
+		line := indent + "//"
+		for _, w := range words {
+			if len(line)+1+len(w) > maxDocLine && len(line) > len(indent)+2 {
+				b.WriteString(line + "\n")
+				line = indent + "//"
+			}
+			line += " " + w
+		}
+		b.WriteString(line + "\n")
+	}
+}
+
+// GoName returns an exported Go identifier for a JSON member or type name, e.g. ToAddress for
+// to_address. Common initialisms are written in upper case.
+func GoName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, part := range parts {
+		if initialisms[strings.ToLower(part)] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	s := b.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
+
+// initialisms are the words GoName writes in upper case, as golint expects.
+var initialisms = map[string]bool{
+	"api": true, "id": true, "ibc": true, "json": true, "url": true, "uri": true, "nft": true,
+}
+
+// UniqueName returns name, or name followed by a number if taken reports that it is already
+// used.
+func UniqueName(name string, taken func(string) bool) string {
+	if !taken(name) {
+		return name
+	}
+	for i := 2; ; i++ {
+		if n := name + strconv.Itoa(i); !taken(n) {
+			return n
+		}
+	}
+}
+
+// Nilable reports whether the zero value of the Go type written as t, e.g. []string, is nil, so
+// that it can hold JSON null without being made a pointer.
+func Nilable(t string) bool {
+	switch t {
+	case "tinyjson.RawMessage", "tinyjson.Binary", "tinyjson.HexBinary":
+		return true
+	}
+	return strings.HasPrefix(t, "*") || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[")
+}
@@ -0,0 +1,119 @@
+package typegen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoName(t *testing.T) {
+	for _, test := range []struct {
+		in, want string
+	}{
+		{"to_address", "ToAddress"},
+		{"amount", "Amount"},
+		{"Cw20Coin", "Cw20Coin"},
+		{"contract_id", "ContractID"},
+		{"ibc_channel", "IBCChannel"},
+		{"Nullable_Config", "NullableConfig"},
+		{"camelCase", "CamelCase"},
+		{"@type", "Type"},
+		{"1st", "X1st"},
+		{"", "X"},
+	} {
+		if got := GoName(test.in); got != test.want {
+			t.Errorf("GoName(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	taken := map[string]bool{"Amount": true, "Amount2": true}
+	if got := UniqueName("Denom", func(n string) bool { return taken[n] }); got != "Denom" {
+		t.Errorf("UniqueName(Denom) = %q", got)
+	}
+	if got := UniqueName("Amount", func(n string) bool { return taken[n] }); got != "Amount3" {
+		t.Errorf("UniqueName(Amount) = %q", got)
+	}
+}
+
+func TestNilable(t *testing.T) {
+	for _, test := range []struct {
+		t    string
+		want bool
+	}{
+		{"*Coin", true},
+		{"[]string", true},
+		{"map[string]Coin", true},
+		{"tinyjson.RawMessage", true},
+		{"tinyjson.Binary", true},
+		{"string", false},
+		{"[4]byte", false},
+		{"tinyjson.Addr", false},
+		{"", false},
+	} {
+		if got := Nilable(test.t); got != test.want {
+			t.Errorf("Nilable(%q) = %v, want %v", test.t, got, test.want)
+		}
+	}
+}
+
+func TestSource(t *testing.T) {
+	f := &File{
+		PkgName: "msgs",
+		Command: "schema2go",
+		Sources: []string{"schema.json"},
+		Decls: []*Decl{
+			{
+				Name: "Coin",
+				Doc:  "Coin is an amount of tokens. " + strings.Repeat("word ", 30),
+				Fields: []Field{
+					{Name: "Denom", Type: "string", JSONName: "denom", Required: true},
+					{Name: "Amount", Type: "uint64", JSONName: "amount", OmitEmpty: true, AsString: true},
+					{Name: "Owner", Type: "tinyjson.Addr", JSONName: "owner", Doc: "Owner of the coin."},
+				},
+			},
+			{Name: "Empty"},
+			{
+				Name:       "Status",
+				Underlying: "string",
+				Consts:     []Const{{Name: "StatusOn", Value: "on", Doc: "On."}, {Name: "StatusOff", Value: "off"}},
+			},
+		},
+	}
+	src, err := f.Source()
+	if err != nil {
+		t.Fatalf("Source() error: %v", err)
+	}
+
+	want := `// Code generated by tinyjson schema2go from schema.json. DO NOT EDIT.
+
+package msgs
+
+import "github.com/CosmWasm/tinyjson"
+
+// Coin is an amount of tokens. word word word word word word word word word word word word word
+// word word word word word word word word word word word word word word word word word
+//
+//tinyjson:json
+type Coin struct {
+	Denom  string ` + "`" + `json:"denom,required"` + "`" + `
+	Amount uint64 ` + "`" + `json:"amount,omitempty,string"` + "`" + `
+	// Owner of the coin.
+	Owner tinyjson.Addr ` + "`" + `json:"owner"` + "`" + `
+}
+
+//tinyjson:json
+type Empty struct{}
+
+type Status string
+
+const (
+	// On.
+	StatusOn  Status = "on"
+	StatusOff Status = "off"
+)
+`
+	if string(src) != want {
+		t.Errorf("Source() =\n%s\nwant\n%s", src, want)
+	}
+}
@@ -0,0 +1,130 @@
+package tinyjson
+
+import (
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// ErrUnsupportedTypeParam is reported when a generic type is encoded or decoded with a type
+// argument that neither implements the tinyjson or encoding/json interfaces nor is a string,
+// bool, integer, []byte or interface{} type, nor is supported by the registered TypeParamCodec.
+var ErrUnsupportedTypeParam = jlexer.NewError("unsupported type argument")
+
+// TypeParamCodec encodes and decodes values of the type arguments EncodeTypeParam and
+// DecodeTypeParam do not support themselves, e.g. floats or named types of primitive kinds.
+type TypeParamCodec interface {
+	// EncodeTypeParam writes v to w and reports whether its type is supported.
+	EncodeTypeParam(w *jwriter.Writer, v interface{}) bool
+	// DecodeTypeParam reads the next value from l into the value v points to and reports
+	// whether its type is supported, without reading anything otherwise.
+	DecodeTypeParam(l *jlexer.Lexer, v interface{}) bool
+}
+
+// typeParamCodec is the registered codec for other type arguments, if any.
+var typeParamCodec TypeParamCodec
+
+// RegisterTypeParamCodec registers the codec used for the type arguments EncodeTypeParam and
+// DecodeTypeParam do not support themselves, such as the one of the reflectparam package, which
+// relies on reflection and is not meant for contracts. It is not safe for concurrent use and is
+// intended to be called from init functions.
+func RegisterTypeParamCodec(codec TypeParamCodec) {
+	typeParamCodec = codec
+}
+
+// EncodeTypeParam writes v, a value of a type parameter, to w. It is used by the code
+// generated for generic types, whose type arguments are only known at run time.
+func EncodeTypeParam(w *jwriter.Writer, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		w.RawString("null")
+	case Marshaler:
+		v.MarshalTinyJSON(w)
+	case interface{ MarshalJSON() ([]byte, error) }:
+		w.Raw(v.MarshalJSON())
+	case string:
+		w.String(v)
+	case bool:
+		w.Bool(v)
+	case int:
+		w.Int(v)
+	case int8:
+		w.Int8(v)
+	case int16:
+		w.Int16(v)
+	case int32:
+		w.Int32(v)
+	case int64:
+		w.Int64(v)
+	case uint:
+		w.Uint(v)
+	case uint8:
+		w.Uint8(v)
+	case uint16:
+		w.Uint16(v)
+	case uint32:
+		w.Uint32(v)
+	case uint64:
+		w.Uint64(v)
+	case []byte:
+		w.Base64Bytes(v)
+	default:
+		if typeParamCodec != nil && typeParamCodec.EncodeTypeParam(w, v) {
+			return
+		}
+		if w.Error == nil {
+			w.Error = ErrUnsupportedTypeParam
+		}
+	}
+}
+
+// DecodeTypeParam reads the next value from l into v, a pointer to a value of a type
+// parameter. It is used by the code generated for generic types.
+func DecodeTypeParam(l *jlexer.Lexer, v interface{}) {
+	switch v := v.(type) {
+	case Unmarshaler:
+		v.UnmarshalTinyJSON(l)
+	case interface{ UnmarshalJSON([]byte) error }:
+		if data := l.Raw(); l.Ok() {
+			l.AddNonFatalError(v.UnmarshalJSON(data))
+		}
+	case *string:
+		*v = l.String()
+	case *bool:
+		*v = l.Bool()
+	case *int:
+		*v = l.Int()
+	case *int8:
+		*v = l.Int8()
+	case *int16:
+		*v = l.Int16()
+	case *int32:
+		*v = l.Int32()
+	case *int64:
+		*v = l.Int64()
+	case *uint:
+		*v = l.Uint()
+	case *uint8:
+		*v = l.Uint8()
+	case *uint16:
+		*v = l.Uint16()
+	case *uint32:
+		*v = l.Uint32()
+	case *uint64:
+		*v = l.Uint64()
+	case *[]byte:
+		if l.IsNull() {
+			l.Skip()
+			*v = nil
+		} else {
+			*v = l.Bytes()
+		}
+	case *interface{}:
+		*v = l.Interface()
+	default:
+		if typeParamCodec != nil && typeParamCodec.DecodeTypeParam(l, v) {
+			return
+		}
+		l.SkipRecursive()
+		l.AddNonFatalError(ErrUnsupportedTypeParam)
+	}
+}
@@ -0,0 +1,410 @@
+package tinyjson
+
+import (
+	"strconv"
+
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// ValueKind is the kind of a JSON value held by a Value.
+type ValueKind uint8
+
+// Kinds of JSON values.
+const (
+	NullKind ValueKind = iota
+	BoolKind
+	NumberKind
+	StringKind
+	ArrayKind
+	ObjectKind
+)
+
+func (k ValueKind) String() string {
+	switch k {
+	case NullKind:
+		return "null"
+	case BoolKind:
+		return "bool"
+	case NumberKind:
+		return "number"
+	case StringKind:
+		return "string"
+	case ArrayKind:
+		return "array"
+	case ObjectKind:
+		return "object"
+	}
+	return "invalid"
+}
+
+// Member is a member of a JSON object.
+type Member struct {
+	Key   string
+	Value Value
+}
+
+// numberType is the Go type a number held by a Value fits in.
+type numberType uint8
+
+const (
+	decimalNumber numberType = iota // neither an int64 nor a uint64, kept as written only
+	intNumber
+	uintNumber
+)
+
+// Value is a JSON document tree, decoded without reflection. Unlike the map[string]interface{}
+// values of jlexer.Lexer.Interface, objects keep their members in order, duplicates included,
+// and numbers are kept as written, as well as stored as int64 or uint64 when they fit, so that
+// a decoded value is encoded back as is. The zero Value is null.
+type Value struct {
+	kind    ValueKind
+	boolean bool
+	text    string // string, or number as written
+	number  numberType
+	bits    uint64 // int64 or uint64 number
+	array   []Value
+	object  []Member
+}
+
+// BoolValue returns the JSON boolean b.
+func BoolValue(b bool) Value {
+	return Value{kind: BoolKind, boolean: b}
+}
+
+// IntValue returns the JSON number n.
+func IntValue(n int64) Value {
+	return Value{kind: NumberKind, text: strconv.FormatInt(n, 10), number: intNumber, bits: uint64(n)}
+}
+
+// UintValue returns the JSON number n.
+func UintValue(n uint64) Value {
+	return Value{kind: NumberKind, text: strconv.FormatUint(n, 10), number: uintNumber, bits: n}
+}
+
+// NumberValue returns the JSON number written as literal, e.g. "1.5e3", or an error if it is not
+// a valid one.
+func NumberValue(literal string) (Value, error) {
+	l := jlexer.Lexer{Data: []byte(literal)}
+	v := numberValue(l.Number())
+	l.Consumed()
+	if err := l.Error(); err != nil {
+		return Value{}, err
+	}
+	return v, nil
+}
+
+// numberValue returns the JSON number written as the valid literal.
+func numberValue(literal string) Value {
+	v := Value{kind: NumberKind, text: literal}
+	if n, err := strconv.ParseInt(literal, 10, 64); err == nil {
+		v.number, v.bits = intNumber, uint64(n)
+	} else if n, err := strconv.ParseUint(literal, 10, 64); err == nil {
+		v.number, v.bits = uintNumber, n
+	}
+	return v
+}
+
+// StringValue returns the JSON string s.
+func StringValue(s string) Value {
+	return Value{kind: StringKind, text: s}
+}
+
+// ArrayValue returns the JSON array of the given elements.
+func ArrayValue(elems ...Value) Value {
+	if elems == nil {
+		elems = []Value{}
+	}
+	return Value{kind: ArrayKind, array: elems}
+}
+
+// ObjectValue returns the JSON object with the given members, in order.
+func ObjectValue(members ...Member) Value {
+	if members == nil {
+		members = []Member{}
+	}
+	return Value{kind: ObjectKind, object: members}
+}
+
+// Kind returns the kind of v.
+func (v Value) Kind() ValueKind {
+	return v.kind
+}
+
+// IsNull reports whether v is null.
+func (v Value) IsNull() bool {
+	return v.kind == NullKind
+}
+
+// Bool returns the boolean v holds and whether it is one.
+func (v Value) Bool() (b, ok bool) {
+	return v.boolean, v.kind == BoolKind
+}
+
+// Text returns the string v holds and whether it is one.
+func (v Value) Text() (string, bool) {
+	if v.kind != StringKind {
+		return "", false
+	}
+	return v.text, true
+}
+
+// Number returns the number v holds as written, e.g. "1.5e3", and whether it is one.
+func (v Value) Number() (string, bool) {
+	if v.kind != NumberKind {
+		return "", false
+	}
+	return v.text, true
+}
+
+// Int64 returns the number v holds and whether it is one and fits in an int64.
+func (v Value) Int64() (int64, bool) {
+	if v.kind != NumberKind || v.number != intNumber {
+		return 0, false
+	}
+	return int64(v.bits), true
+}
+
+// Uint64 returns the number v holds and whether it is one and fits in a uint64.
+func (v Value) Uint64() (uint64, bool) {
+	if v.kind != NumberKind || v.number == decimalNumber || v.number == intNumber && int64(v.bits) < 0 {
+		return 0, false
+	}
+	return v.bits, true
+}
+
+// Len returns the number of elements of an array, or of members of an object, and 0 for other
+// values.
+func (v Value) Len() int {
+	switch v.kind {
+	case ArrayKind:
+		return len(v.array)
+	case ObjectKind:
+		return len(v.object)
+	}
+	return 0
+}
+
+// Elems returns the elements of an array, nil for other values. They are shared with v.
+func (v Value) Elems() []Value {
+	return v.array
+}
+
+// Index returns the i-th element of an array, or null if v is not an array or i is out of range.
+func (v Value) Index(i int) Value {
+	if i < 0 || i >= len(v.array) {
+		return Value{}
+	}
+	return v.array[i]
+}
+
+// Members returns the members of an object, in order, nil for other values. They are shared
+// with v.
+func (v Value) Members() []Member {
+	return v.object
+}
+
+// Get returns the value of the member of an object with the given key, the last one if the key
+// is duplicated, as when decoding into a struct, and whether there is one.
+func (v Value) Get(key string) (Value, bool) {
+	for i := len(v.object) - 1; i >= 0; i-- {
+		if v.object[i].Key == key {
+			return v.object[i].Value, true
+		}
+	}
+	return Value{}, false
+}
+
+// Lookup returns the value at the given path and whether there is one: each element of the path
+// is the key of an object member or the decimal index of an array element, e.g.
+// v.Lookup("balances", "0", "amount").
+func (v Value) Lookup(path ...string) (Value, bool) {
+	for _, p := range path {
+		switch v.kind {
+		case ObjectKind:
+			next, ok := v.Get(p)
+			if !ok {
+				return Value{}, false
+			}
+			v = next
+		case ArrayKind:
+			i, err := strconv.Atoi(p)
+			if err != nil || i < 0 || i >= len(v.array) {
+				return Value{}, false
+			}
+			v = v.array[i]
+		default:
+			return Value{}, false
+		}
+	}
+	return v, true
+}
+
+// Set sets the value of the member of an object with the given key, replacing the last one if
+// the key is present, appending a member otherwise. A null v becomes an object first. It panics
+// if v is of another kind.
+func (v *Value) Set(key string, value Value) {
+	v.makeKind(ObjectKind, "Set")
+	for i := len(v.object) - 1; i >= 0; i-- {
+		if v.object[i].Key == key {
+			v.object[i].Value = value
+			return
+		}
+	}
+	v.object = append(v.object, Member{Key: key, Value: value})
+}
+
+// Delete removes the members of an object with the given key, keeping the order of the others,
+// and reports whether there were any.
+func (v *Value) Delete(key string) bool {
+	kept := v.object[:0]
+	for _, m := range v.object {
+		if m.Key != key {
+			kept = append(kept, m)
+		}
+	}
+	deleted := len(kept) < len(v.object)
+	v.object = kept
+	return deleted
+}
+
+// Append appends elements to an array. A null v becomes an array first. It panics if v is of
+// another kind.
+func (v *Value) Append(elems ...Value) {
+	v.makeKind(ArrayKind, "Append")
+	v.array = append(v.array, elems...)
+}
+
+// SetIndex sets the i-th element of an array. It panics if v is not an array or i is out of
+// range.
+func (v *Value) SetIndex(i int, value Value) {
+	if v.kind != ArrayKind {
+		panic("tinyjson: Value.SetIndex on " + v.kind.String() + " value")
+	}
+	v.array[i] = value
+}
+
+// makeKind turns a null v into an empty value of the given kind, for the method with the given
+// name, which panics if v is of another kind.
+func (v *Value) makeKind(kind ValueKind, method string) {
+	switch v.kind {
+	case kind:
+	case NullKind:
+		*v = Value{kind: kind}
+	default:
+		panic("tinyjson: Value." + method + " on " + v.kind.String() + " value")
+	}
+}
+
+// MarshalTinyJSON does JSON marshaling using tinyjson interface.
+func (v Value) MarshalTinyJSON(w *jwriter.Writer) {
+	switch v.kind {
+	case BoolKind:
+		w.Bool(v.boolean)
+	case NumberKind:
+		w.RawString(v.text)
+	case StringKind:
+		w.String(v.text)
+	case ArrayKind:
+		w.RawByte('[')
+		for i, e := range v.array {
+			if i > 0 {
+				w.RawByte(',')
+			}
+			e.MarshalTinyJSON(w)
+		}
+		w.RawByte(']')
+	case ObjectKind:
+		w.RawByte('{')
+		for i, m := range v.object {
+			if i > 0 {
+				w.RawByte(',')
+			}
+			w.String(m.Key)
+			w.RawByte(':')
+			m.Value.MarshalTinyJSON(w)
+		}
+		w.RawByte('}')
+	default:
+		w.RawString("null")
+	}
+}
+
+// UnmarshalTinyJSON does JSON unmarshaling using tinyjson interface.
+func (v *Value) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	isTopLevel := l.IsStart()
+	switch {
+	case l.IsNull():
+		l.Skip()
+		*v = Value{}
+	case l.IsBool():
+		*v = BoolValue(l.Bool())
+	case l.IsNumber():
+		*v = numberValue(l.Number())
+	case l.IsString():
+		*v = StringValue(l.String())
+	case l.IsDelim('['):
+		l.Delim('[')
+		elems := []Value{}
+		for l.Ok() && !l.IsDelim(']') {
+			var e Value
+			e.UnmarshalTinyJSON(l)
+			elems = append(elems, e)
+			l.WantComma()
+		}
+		l.Delim(']')
+		*v = Value{kind: ArrayKind, array: elems}
+	case l.IsDelim('{'):
+		l.Delim('{')
+		members := []Member{}
+		for l.Ok() && !l.IsDelim('}') {
+			var m Member
+			m.Key = l.String()
+			l.WantColon()
+			m.Value.UnmarshalTinyJSON(l)
+			members = append(members, m)
+			l.WantComma()
+		}
+		l.Delim('}')
+		*v = Value{kind: ObjectKind, object: members}
+	default:
+		l.AddError(&jlexer.LexerError{
+			Reason: "expected a JSON value",
+			Err:    jlexer.ErrSyntax,
+			Offset: l.TokenStart(),
+		})
+	}
+	if isTopLevel {
+		l.Consumed()
+	}
+	if !l.Ok() {
+		*v = Value{}
+	}
+}
+
+// MarshalJSON implements encoding/json.Marshaler interface.
+func (v Value) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	v.MarshalTinyJSON(&w)
+	return w.BuildBytes()
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler interface.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	v.UnmarshalTinyJSON(&l)
+	return l.Error()
+}
+
+// IsDefined is required for integration with omitempty tinyjson logic: null values are omitted.
+func (v *Value) IsDefined() bool {
+	return v.kind != NullKind
+}
+
+// String returns the JSON encoding of v.
+func (v Value) String() string {
+	w := jwriter.Writer{}
+	v.MarshalTinyJSON(&w)
+	data, _ := w.BuildBytes()
+	return string(data)
+}
@@ -0,0 +1,151 @@
+package tinyjson
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValueRoundTrip(t *testing.T) {
+	for _, data := range []string{
+		`null`,
+		`true`,
+		`"a\"b"`,
+		`[]`,
+		`{}`,
+		`{"z":1,"a":[1.50,-0,1e400,18446744073709551616],"m":{"b":null,"a":false},"z":"dup"}`,
+	} {
+		var v Value
+		if err := v.UnmarshalJSON([]byte(data)); err != nil {
+			t.Fatalf("UnmarshalJSON(%s) error: %v", data, err)
+		}
+		if got := v.String(); got != data {
+			t.Errorf("UnmarshalJSON(%s) encodes to %s", data, got)
+		}
+	}
+
+	for _, data := range []string{`{"a":}`, `[1,]`, `01`, `1.`, `]`, `{"a" 1}`, `{"a":1} garbage`, `1 2`} {
+		var v Value
+		if err := v.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) = %v; want error", data, v)
+		} else if !v.IsNull() {
+			t.Errorf("UnmarshalJSON(%s) = %v; want null on error", data, v)
+		}
+	}
+}
+
+func TestValueNumbers(t *testing.T) {
+	for _, test := range []struct {
+		literal   string
+		i         int64
+		iOK       bool
+		u         uint64
+		uOK       bool
+		wantError bool
+	}{
+		{literal: "-42", i: -42, iOK: true},
+		{literal: "42", i: 42, iOK: true, u: 42, uOK: true},
+		{literal: "18446744073709551615", u: 18446744073709551615, uOK: true},
+		{literal: "18446744073709551616"},
+		{literal: "1.5"},
+		{literal: "1e3"},
+		{literal: "1 2", wantError: true},
+		{literal: "0x10", wantError: true},
+	} {
+		v, err := NumberValue(test.literal)
+		if test.wantError {
+			if err == nil {
+				t.Errorf("NumberValue(%s) = %v; want error", test.literal, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("NumberValue(%s) error: %v", test.literal, err)
+		}
+		if s, ok := v.Number(); !ok || s != test.literal {
+			t.Errorf("NumberValue(%s).Number() = %s, %v", test.literal, s, ok)
+		}
+		if i, ok := v.Int64(); i != test.i || ok != test.iOK {
+			t.Errorf("NumberValue(%s).Int64() = %d, %v; want %d, %v", test.literal, i, ok, test.i, test.iOK)
+		}
+		if u, ok := v.Uint64(); u != test.u || ok != test.uOK {
+			t.Errorf("NumberValue(%s).Uint64() = %d, %v; want %d, %v", test.literal, u, ok, test.u, test.uOK)
+		}
+	}
+
+	if got := IntValue(-7).String(); got != "-7" {
+		t.Errorf("IntValue(-7) = %s", got)
+	}
+	if u, ok := UintValue(7).Uint64(); !ok || u != 7 {
+		t.Errorf("UintValue(7).Uint64() = %d, %v", u, ok)
+	}
+}
+
+func TestValueNavigation(t *testing.T) {
+	var v Value
+	data := `{"balances":[{"denom":"uatom","amount":"10"}],"count":2,"count":3}`
+	if err := v.UnmarshalJSON([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	if amount, ok := v.Lookup("balances", "0", "amount"); !ok {
+		t.Error(`Lookup("balances", "0", "amount") not found`)
+	} else if s, _ := amount.Text(); s != "10" {
+		t.Errorf(`Lookup("balances", "0", "amount") = %v`, amount)
+	}
+	for _, path := range [][]string{{"missing"}, {"balances", "1"}, {"balances", "x"}, {"count", "a"}} {
+		if got, ok := v.Lookup(path...); ok {
+			t.Errorf("Lookup(%q) = %v; want not found", path, got)
+		}
+	}
+	if count, _ := v.Get("count"); count.String() != "3" {
+		t.Errorf(`Get("count") = %v; want the last member`, count)
+	}
+	if v.Len() != 3 || v.Members()[0].Key != "balances" || v.Index(0).Kind() != NullKind {
+		t.Errorf("Len(), Members() or Index() of %v is wrong", v)
+	}
+	if balances, _ := v.Get("balances"); balances.Kind() != ArrayKind || balances.Index(0).Kind() != ObjectKind {
+		t.Errorf(`Get("balances") = %v`, balances)
+	}
+}
+
+func TestValueMutation(t *testing.T) {
+	var v Value
+	v.Set("b", StringValue("x"))
+	v.Set("a", BoolValue(true))
+	v.Set("b", IntValue(1))
+
+	var list Value
+	list.Append(IntValue(1), StringValue("two"))
+	list.SetIndex(0, Value{})
+	v.Set("list", list)
+
+	if got, want := v.String(), `{"b":1,"a":true,"list":[null,"two"]}`; got != want {
+		t.Errorf("String() = %s; want %s", got, want)
+	}
+	if !v.Delete("a") || v.Delete("a") {
+		t.Error("Delete() reports wrong results")
+	}
+	if got, want := v.String(), `{"b":1,"list":[null,"two"]}`; got != want {
+		t.Errorf("String() after Delete() = %s; want %s", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Append() on an object did not panic")
+		}
+	}()
+	v.Append(IntValue(1))
+}
+
+func TestValueErrorKind(t *testing.T) {
+	var v Value
+	err := v.UnmarshalJSON([]byte(`[1,2}`))
+	if !errors.Is(err, ErrSyntax) {
+		t.Errorf("UnmarshalJSON() error = %v; want ErrSyntax", err)
+	}
+
+	err = Unmarshal([]byte(`{"a":1} garbage`), &v)
+	if !errors.Is(err, ErrTrailingData) || !v.IsNull() {
+		t.Errorf("Unmarshal() of trailing data = %v, error %v; want null and ErrTrailingData", v, err)
+	}
+}